@@ -0,0 +1,48 @@
+package weatherstack
+
+import (
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// Anomaly returns how much warmer (positive) or cooler (negative) AvgTemp is than baselineAvg,
+// which must be in the units the data was requested in
+func (w Weather) Anomaly(baselineAvg float64) float64 {
+	return w.AvgTemp.Value() - baselineAvg
+}
+
+type TemperatureAnomaly struct {
+	Date    civil.Date
+	Anomaly float64
+}
+
+// Anomalies returns the temperature anomaly per day in chronological order.
+// baseline holds the average temperature per day of year (1-366), days without baseline are skipped.
+func (r *HistoricalResponse) Anomalies(baseline map[int]float64) []TemperatureAnomaly {
+	anomalies := []TemperatureAnomaly{}
+
+	for key, day := range r.Historical {
+		date, err := civil.ParseDate(key)
+		if err != nil {
+			continue
+		}
+
+		baselineAvg, ok := baseline[date.In(time.UTC).YearDay()]
+		if !ok {
+			continue
+		}
+
+		anomalies = append(anomalies, TemperatureAnomaly{
+			Date:    date,
+			Anomaly: day.Anomaly(baselineAvg),
+		})
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		return anomalies[i].Date.Before(anomalies[j].Date)
+	})
+
+	return anomalies
+}
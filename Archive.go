@@ -0,0 +1,125 @@
+package weatherstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// ObjectWriter opens a writer for the object name, e.g. for Google Cloud Storage:
+//
+//	func(ctx context.Context, name string) (io.WriteCloser, error) {
+//		return bucket.Object(name).NewWriter(ctx), nil
+//	}
+//
+// The object must only be committed when Close returns without error.
+type ObjectWriter func(ctx context.Context, name string) (io.WriteCloser, error)
+
+type StoreOptions struct {
+	Raw bool // also store RawResponse (requires ServiceConfig.IncludeRawResponse) as <prefix>/<location>/raw/<first date>_<last date>.json
+}
+
+// archivedHour is a line of an archived day
+type archivedHour struct {
+	Location string `json:"location"`
+	Date     string `json:"date"`
+	HourlyWeather
+}
+
+// StoreResponse archives a historical response as newline-delimited JSON, one object per day
+// named <prefix>/<location>/<date>.ndjson holding a line per hourly record. <location> is <name>_<region>_<country>
+// of the location, each lower case with other characters than letters and digits replaced by "-",
+// so that locations sharing a name are stored apart.
+func StoreResponse(ctx context.Context, newWriter ObjectWriter, prefix string, response *HistoricalResponse, options StoreOptions) *errortools.Error {
+	if locationSlug(response.Location.Name) == "" {
+		return errortools.ErrorMessage("Response has no location name")
+	}
+
+	location := locationPartition(response.Location)
+
+	dates := sortedDates(response.Historical)
+
+	for _, date := range dates {
+		day := response.Historical[date.String()]
+
+		lines := make([][]byte, 0, len(day.Hourly))
+		for _, hourly := range day.Hourly {
+			line, err := json.Marshal(archivedHour{
+				Location:      response.Location.Name,
+				Date:          date.String(),
+				HourlyWeather: hourly,
+			})
+			if err != nil {
+				return errortools.ErrorMessage(err)
+			}
+			lines = append(lines, line)
+		}
+
+		name := path.Join(prefix, location, date.String()+".ndjson")
+		if e := writeObject(ctx, newWriter, name, lines, []byte("\n")); e != nil {
+			return e
+		}
+	}
+
+	if options.Raw {
+		if response.RawResponse == nil {
+			return errortools.ErrorMessage("Response has no RawResponse, set ServiceConfig.IncludeRawResponse")
+		}
+		if len(dates) == 0 {
+			return errortools.ErrorMessage("Response has no dates")
+		}
+
+		name := path.Join(prefix, location, "raw", fmt.Sprintf("%s_%s.json", dates[0], dates[len(dates)-1]))
+		if e := writeObject(ctx, newWriter, name, [][]byte{response.RawResponse}, nil); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func writeObject(ctx context.Context, newWriter ObjectWriter, name string, lines [][]byte, separator []byte) *errortools.Error {
+	writer, err := newWriter(ctx, name)
+	if err != nil {
+		return errortools.ErrorMessagef("Opening %s failed: %s", name, err.Error())
+	}
+
+	for _, line := range lines {
+		if _, err = writer.Write(append(line, separator...)); err != nil {
+			writer.Close()
+			return errortools.ErrorMessagef("Writing %s failed: %s", name, err.Error())
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return errortools.ErrorMessagef("Closing %s failed: %s", name, err.Error())
+	}
+
+	return nil
+}
+
+// locationPartition joins the slugs of name, region and country by "_", which slugs do not contain
+func locationPartition(location Location) string {
+	return strings.Join([]string{locationSlug(location.Name), locationSlug(location.Region), locationSlug(location.Country)}, "_")
+}
+
+func locationSlug(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, strings.TrimSpace(name))
+
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+
+	return strings.Trim(slug, "-")
+}
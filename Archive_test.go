@@ -0,0 +1,48 @@
+package weatherstack
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestStoreResponseSeparatesLocationsSharingAName(t *testing.T) {
+	objects := map[string]*bytes.Buffer{}
+	newWriter := func(ctx context.Context, name string) (io.WriteCloser, error) {
+		objects[name] = &bytes.Buffer{}
+		return nopWriteCloser{objects[name]}, nil
+	}
+
+	for _, location := range []Location{
+		{Name: "Springfield", Region: "Illinois", Country: "United States of America"},
+		{Name: "Springfield", Region: "Missouri", Country: "United States of America"},
+		{Name: "Springfield", Country: "Australia"},
+	} {
+		response := &HistoricalResponse{
+			Location:   location,
+			Historical: map[string]HistoricalWeather{"2021-09-09": {Hourly: []HourlyWeather{{}}}},
+		}
+		if e := StoreResponse(context.Background(), newWriter, "archive", response, StoreOptions{}); e != nil {
+			t.Fatal(e.Message())
+		}
+	}
+
+	for _, name := range []string{
+		"archive/springfield_illinois_united-states-of-america/2021-09-09.ndjson",
+		"archive/springfield_missouri_united-states-of-america/2021-09-09.ndjson",
+		"archive/springfield__australia/2021-09-09.ndjson",
+	} {
+		if objects[name] == nil || objects[name].Len() == 0 {
+			t.Errorf("no object %s, got %v objects", name, len(objects))
+		}
+	}
+}
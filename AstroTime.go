@@ -0,0 +1,47 @@
+package weatherstack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+// ErrNoAstroEvent is returned when the event does not occur on the date, e.g. "No sunrise" in polar regions
+var ErrNoAstroEvent = errors.New("no such astronomical event on this date")
+
+func (a Astro) SunriseTime(date civil.Date, loc *time.Location) (time.Time, error) {
+	return astroTime(a.Sunrise, date, loc)
+}
+
+func (a Astro) SunsetTime(date civil.Date, loc *time.Location) (time.Time, error) {
+	return astroTime(a.Sunset, date, loc)
+}
+
+func (a Astro) MoonriseTime(date civil.Date, loc *time.Location) (time.Time, error) {
+	return astroTime(a.Moonrise, date, loc)
+}
+
+func (a Astro) MoonsetTime(date civil.Date, loc *time.Location) (time.Time, error) {
+	return astroTime(a.Moonset, date, loc)
+}
+
+func astroTime(timeStruct w_types.TimeStruct, date civil.Date, loc *time.Location) (time.Time, error) {
+	if timeStruct.TimeTime == nil {
+		if strings.HasPrefix(strings.ToLower(timeStruct.TimeString), "no ") {
+			return time.Time{}, ErrNoAstroEvent
+		}
+		return time.Time{}, fmt.Errorf("invalid astro time: %s", timeStruct.TimeString)
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t := *timeStruct.TimeTime
+
+	return time.Date(date.Year, date.Month, date.Day, t.Hour(), t.Minute(), 0, 0, loc), nil
+}
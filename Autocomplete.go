@@ -0,0 +1,89 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	go_http "github.com/leapforce-libraries/go_http"
+	go_types "github.com/leapforce-libraries/go_types"
+)
+
+type AutocompleteResponse struct {
+	Request     AutocompleteRequest  `json:"request"`
+	Results     []AutocompleteResult `json:"results"`
+	RawResponse []byte               `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true
+	Meta        *ResponseMeta        `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true and the response was not cached
+}
+
+type AutocompleteRequest struct {
+	Query   string `json:"query"`
+	Results int64  `json:"results"`
+}
+
+type AutocompleteResult struct {
+	ID         int64                  `json:"id"`
+	Name       string                 `json:"name"`
+	Country    string                 `json:"country"`
+	Region     string                 `json:"region"`
+	Lat        go_types.Float64String `json:"lat"`
+	Lon        go_types.Float64String `json:"lon"`
+	TimezoneID string                 `json:"timezone_id"`
+	UTCOffset  go_types.Float64String `json:"utc_offset"`
+}
+
+func (service *Service) Autocomplete(query string) (*AutocompleteResponse, *RequestError) {
+	return service.AutocompleteWithContext(context.Background(), query)
+}
+
+// SearchLocations returns the locations matching query, e.g. to resolve an ambiguous city name before requesting its weather.
+// No matches yield an empty slice.
+func (service *Service) SearchLocations(query string) ([]AutocompleteResult, *RequestError) {
+	return service.SearchLocationsWithContext(context.Background(), query)
+}
+
+func (service *Service) SearchLocationsWithContext(ctx context.Context, query string) ([]AutocompleteResult, *RequestError) {
+	autocompleteResponse, e := service.AutocompleteWithContext(ctx, query)
+	if e != nil {
+		return nil, e
+	}
+
+	return autocompleteResponse.Results, nil
+}
+
+func (service *Service) AutocompleteWithContext(ctx context.Context, query string) (*AutocompleteResponse, *RequestError) {
+	if strings.TrimSpace(query) == "" {
+		return nil, newRequestError(errortools.ErrorMessage("Query not provided"), nil)
+	}
+	if e := validateQuery(query); e != nil {
+		return nil, newRequestError(e, nil)
+	}
+
+	values := url.Values{}
+
+	values.Add("query", query)
+
+	autocompleteResponse := AutocompleteResponse{}
+
+	requestConfig := go_http.RequestConfig{
+		URL:           service.url(fmt.Sprintf("autocomplete?%s", values.Encode())),
+		ResponseModel: &autocompleteResponse,
+	}
+
+	_, _, e := service.get(ctx, &requestConfig)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := autocompleteResponse.Validate(); e != nil {
+		return nil, newRequestError(e, nil)
+	}
+
+	if autocompleteResponse.Results == nil {
+		autocompleteResponse.Results = []AutocompleteResult{}
+	}
+
+	return &autocompleteResponse, nil
+}
@@ -0,0 +1,281 @@
+package weatherstack
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// CheckpointStore persists the chunks of a backfill job that completed, so that an interrupted job can resume.
+// Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	Completed(jobID string) (map[string]bool, error) // the keys of the completed chunks
+	MarkCompleted(jobID string, chunk string) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore for the lifetime of the process, e.g. for retrying a job within a run
+type MemoryCheckpointStore struct {
+	mutex     sync.Mutex
+	completed map[string]map[string]bool
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		completed: make(map[string]map[string]bool),
+	}
+}
+
+func (store *MemoryCheckpointStore) Completed(jobID string) (map[string]bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	completed := make(map[string]bool, len(store.completed[jobID]))
+	for chunk := range store.completed[jobID] {
+		completed[chunk] = true
+	}
+
+	return completed, nil
+}
+
+func (store *MemoryCheckpointStore) MarkCompleted(jobID string, chunk string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.completed[jobID] == nil {
+		store.completed[jobID] = make(map[string]bool)
+	}
+	store.completed[jobID][chunk] = true
+
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore appending the completed chunks of each job to <Directory>/<job id>.checkpoint,
+// one per line. The job id is base64url encoded in the file name, so distinct ids never share a file.
+type FileCheckpointStore struct {
+	Directory string // created if it does not exist
+	mutex     sync.Mutex
+}
+
+func (store *FileCheckpointStore) Completed(jobID string) (map[string]bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	completed := make(map[string]bool)
+
+	file, err := os.Open(store.fileName(jobID))
+	if os.IsNotExist(err) {
+		return completed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if chunk := strings.TrimSpace(scanner.Text()); chunk != "" {
+			completed[chunk] = true
+		}
+	}
+
+	return completed, scanner.Err()
+}
+
+func (store *FileCheckpointStore) MarkCompleted(jobID string, chunk string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := os.MkdirAll(store.Directory, 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(store.fileName(jobID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(chunk + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func (store *FileCheckpointStore) fileName(jobID string) string {
+	return filepath.Join(store.Directory, base64.RawURLEncoding.EncodeToString([]byte(jobID))+".checkpoint")
+}
+
+// BackfillJob requests the historical weather of each query from StartDate through EndDate in chunks of
+// at most MaxDaysPerCall days, passing each chunk to Handle. A chunk is checkpointed once Handle returns without error,
+// so running a job with the same ID again only requests the chunks that did not complete.
+type BackfillJob struct {
+	ID          string // identifies the checkpoints of the job
+	Queries     []string
+	StartDate   civil.Date
+	EndDate     civil.Date
+	Config      GetHistoricalWeatherConfig // parameters of each request, Query, Coordinates, StartDate and EndDate being ignored
+	Handle      func(query string, response *HistoricalResponse) error
+	Checkpoints CheckpointStore // defaults to a new MemoryCheckpointStore
+	Retry       *RetryConfig    // retrying of chunks failing transiently or in Handle on top of the retries of the service, defaults to 3 attempts
+	StopOnError bool            // stop after the first chunk that failed all attempts, otherwise the other chunks are still requested
+}
+
+// BackfillChunk is a query along with a date range of a backfill job
+type BackfillChunk struct {
+	Query     string
+	StartDate civil.Date
+	EndDate   civil.Date
+}
+
+func (chunk BackfillChunk) key() string {
+	return fmt.Sprintf("%s|%s|%s", chunk.Query, chunk.StartDate, chunk.EndDate)
+}
+
+type BackfillFailure struct {
+	Chunk    BackfillChunk
+	Attempts int
+	Error    *RequestError
+}
+
+type BackfillSummary struct {
+	Chunks    int // total number of chunks of the job
+	Skipped   int // completed in an earlier run
+	Completed int // completed in this run
+	Failures  []BackfillFailure
+	Duration  time.Duration
+}
+
+// RunBackfill runs job, returning an error only if the job is invalid or the checkpoints cannot be read or written.
+// Chunks that failed are listed in the summary.
+func (service *Service) RunBackfill(job BackfillJob) (*BackfillSummary, *RequestError) {
+	return service.RunBackfillWithContext(context.Background(), job)
+}
+
+// RunBackfillWithContext runs job like RunBackfill, returning the summary so far along with an error if ctx is done
+func (service *Service) RunBackfillWithContext(ctx context.Context, job BackfillJob) (*BackfillSummary, *RequestError) {
+	started := time.Now()
+
+	if job.ID == "" {
+		return nil, newRequestError(errortools.ErrorMessage("ID not provided"), nil)
+	}
+	if job.Handle == nil {
+		return nil, newRequestError(errortools.ErrorMessage("Handle not provided"), nil)
+	}
+	if job.EndDate.Before(job.StartDate) {
+		return nil, newRequestError(errortools.ErrorMessage("EndDate must not be before StartDate"), nil)
+	}
+
+	checkpoints := job.Checkpoints
+	if checkpoints == nil {
+		checkpoints = NewMemoryCheckpointStore()
+	}
+
+	completed, err := checkpoints.Completed(job.ID)
+	if err != nil {
+		return nil, newRequestError(errortools.ErrorMessagef("Reading checkpoints failed: %s", err.Error()), err)
+	}
+
+	retry := newRetryConfig(job.Retry)
+	summary := BackfillSummary{}
+
+	for _, query := range job.Queries {
+		config := job.Config
+		config.Query = query
+		config.Coordinates = nil
+		config.StartDate = job.StartDate
+		config.EndDate = &job.EndDate
+
+		for _, window := range historicalWindows(config) {
+			chunk := BackfillChunk{Query: query, StartDate: window.StartDate, EndDate: *window.EndDate}
+			summary.Chunks++
+
+			if completed[chunk.key()] {
+				summary.Skipped++
+				continue
+			}
+
+			attempts, e := service.runBackfillChunk(ctx, job, window, retry)
+			if err := ctx.Err(); err != nil {
+				summary.Duration = time.Since(started)
+				return &summary, requestErrorOf(err)
+			}
+
+			if e != nil {
+				summary.Failures = append(summary.Failures, BackfillFailure{Chunk: chunk, Attempts: attempts, Error: e})
+				if job.StopOnError {
+					summary.Duration = time.Since(started)
+					return &summary, nil
+				}
+				continue
+			}
+
+			if err := checkpoints.MarkCompleted(job.ID, chunk.key()); err != nil {
+				summary.Duration = time.Since(started)
+				return &summary, newRequestError(errortools.ErrorMessagef("Writing checkpoint failed: %s", err.Error()), err)
+			}
+			summary.Completed++
+		}
+	}
+
+	summary.Duration = time.Since(started)
+
+	return &summary, nil
+}
+
+// runBackfillChunk requests and handles a chunk, retrying failures of Handle and transient request failures according to retry
+func (service *Service) runBackfillChunk(ctx context.Context, job BackfillJob, config GetHistoricalWeatherConfig, retry RetryConfig) (int, *RequestError) {
+	delay := retry.BaseDelay
+
+	for attempt := 1; ; attempt++ {
+		historicalResponse, e := service.GetHistoricalWeatherWithContext(ctx, config)
+		if e != nil && !isTransient(e) {
+			return attempt, e
+		}
+		if e == nil {
+			if err := job.Handle(config.Query, historicalResponse); err != nil {
+				e = newRequestError(errortools.ErrorMessagef("Handle failed: %s", err.Error()), err)
+			}
+		}
+		if e == nil || attempt >= retry.MaxAttempts {
+			return attempt, e
+		}
+
+		if !sleep(ctx, delay) {
+			return attempt, e
+		}
+
+		delay *= 2
+		if delay > retry.MaxDelay {
+			delay = retry.MaxDelay
+		}
+	}
+}
+
+// isTransient reports whether a failed request may succeed when sent again later. Weatherstack API errors
+// (e.g. an invalid access key, historical data not supported by the plan, an invalid historical date) are deterministic,
+// as are the exhausted quota and budget, offline cache and fixture misses and invalid configs, which are refused before
+// any request is sent.
+func isTransient(e *RequestError) bool {
+	if apiError, ok := WeatherstackErrorOf(e); ok {
+		code := apiError.Code
+		return !code.IsAuthError() && !code.IsQuotaError() && !code.IsPlanError() && !code.IsInvalidRequest()
+	}
+
+	if errors.Is(e, ErrQuotaExhausted) || errors.Is(e, ErrUsageBudgetExceeded) || errors.Is(e, ErrCacheMiss) || errors.Is(e, ErrFixtureNotFound) {
+		return false
+	}
+
+	return e.Err != nil || e.Attempts > 0
+}
@@ -0,0 +1,107 @@
+package weatherstack
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestFileCheckpointStoreSeparatesJobIDs(t *testing.T) {
+	store := &FileCheckpointStore{Directory: t.TempDir()}
+
+	// these ids all have the slug "a-b"
+	jobIDs := []string{"a/b", "a b", "A-B", "a--b", "../a b"}
+
+	for _, jobID := range jobIDs {
+		if err := store.MarkCompleted(jobID, "chunk of "+jobID); err != nil {
+			t.Fatalf("MarkCompleted(%q) error = %s", jobID, err)
+		}
+	}
+
+	for _, jobID := range jobIDs {
+		completed, err := store.Completed(jobID)
+		if err != nil {
+			t.Fatalf("Completed(%q) error = %s", jobID, err)
+		}
+		if len(completed) != 1 || !completed["chunk of "+jobID] {
+			t.Errorf("Completed(%q) = %v", jobID, completed)
+		}
+	}
+}
+
+func TestRunBackfillRetriesOnlyTransientFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		wantAttempts int
+	}{
+		{"invalid access key", http.StatusOK, `{"success":false,"error":{"code":101,"type":"invalid_access_key"}}`, 1},
+		{"historical not supported", http.StatusOK, `{"success":false,"error":{"code":603,"type":"historical_queries_not_supported_on_plan"}}`, 1},
+		{"invalid historical date", http.StatusOK, `{"success":false,"error":{"code":611,"type":"invalid_historical_date"}}`, 1},
+		{"service unavailable", http.StatusServiceUnavailable, ``, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(test.statusCode)
+				w.Write([]byte(test.body))
+			}, ServiceConfig{})
+
+			day := civil.Date{Year: 2021, Month: 9, Day: 9}
+			summary, e := service.RunBackfill(BackfillJob{
+				ID:        "job",
+				Queries:   []string{"Amsterdam"},
+				StartDate: day,
+				EndDate:   day,
+				Handle:    func(query string, response *HistoricalResponse) error { return nil },
+				Retry:     &RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			})
+			if e != nil {
+				t.Fatalf("RunBackfill() error = %s", e.Message())
+			}
+
+			if len(summary.Failures) != 1 || summary.Failures[0].Attempts != test.wantAttempts || requests != test.wantAttempts {
+				t.Errorf("got %v requests and failures %+v, want %v attempts", requests, summary.Failures, test.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRunBackfillRetriesHandle(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/historical_scientific.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}, ServiceConfig{})
+
+	handled := 0
+	day := civil.Date{Year: 2021, Month: 9, Day: 9}
+	summary, e := service.RunBackfill(BackfillJob{
+		ID:        "job",
+		Queries:   []string{"Amsterdam"},
+		StartDate: day,
+		EndDate:   day,
+		Handle: func(query string, response *HistoricalResponse) error {
+			handled++
+			return errors.New("store unavailable")
+		},
+		Retry: &RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	})
+	if e != nil {
+		t.Fatalf("RunBackfill() error = %s", e.Message())
+	}
+
+	if handled != 3 || len(summary.Failures) != 1 || summary.Failures[0].Attempts != 3 {
+		t.Errorf("Handle called %v times, failures %+v, want 3 attempts", handled, summary.Failures)
+	}
+}
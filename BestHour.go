@@ -0,0 +1,58 @@
+package weatherstack
+
+import (
+	"math"
+)
+
+// BestHourConfig configures the scoring of BestHour.
+// Each hour gets a penalty, the hour with the lowest penalty is the best hour:
+//
+//	TemperatureWeight * |temperature - TargetTemperature| (°C)
+//	+ RainWeight * ChanceOfRain / 10 (%)
+//	+ WindWeight * WindSpeed / 10 (km/h)
+//	+ DaylightWeight * 10 when the hour is not between sunrise and sunset
+//
+// A weight of 0 ignores the criterion.
+type BestHourConfig struct {
+	TargetTemperature float64 // in the units the data was requested in
+	TemperatureWeight float64
+	RainWeight        float64
+	WindWeight        float64
+	DaylightWeight    float64
+}
+
+// BestHour returns the hourly record with the lowest penalty per config, ok is false if there are no hourly records
+func (w Weather) BestHour(config BestHourConfig) (*HourlyWeather, bool) {
+	targetC := temperatureToCelsius(config.TargetTemperature, w.units)
+
+	sunrise := w.Astro.Sunrise.ValueTime()
+	sunset := w.Astro.Sunset.ValueTime()
+
+	var bestHour *HourlyWeather
+	bestPenalty := math.Inf(1)
+
+	for i := range w.Hourly {
+		hourly := w.Hourly[i]
+
+		penalty := config.TemperatureWeight * math.Abs(hourly.TemperatureValue().Celsius()-targetC)
+		penalty += config.RainWeight * float64(hourly.ChanceOfRain.Value()) / 10
+		penalty += config.WindWeight * hourly.WindSpeedValue().KmH() / 10
+
+		if sunrise != nil && sunset != nil {
+			minutes := int(hourly.Time)/100*60 + int(hourly.Time)%100
+			sunriseMinutes := sunrise.Hour()*60 + sunrise.Minute()
+			sunsetMinutes := sunset.Hour()*60 + sunset.Minute()
+
+			if minutes < sunriseMinutes || minutes >= sunsetMinutes {
+				penalty += config.DaylightWeight * 10
+			}
+		}
+
+		if penalty < bestPenalty {
+			bestHour = &hourly
+			bestPenalty = penalty
+		}
+	}
+
+	return bestHour, bestHour != nil
+}
@@ -0,0 +1,23 @@
+package weatherstack
+
+import "testing"
+
+func TestBestHourUsesRequestUnits(t *testing.T) {
+	day := Weather{
+		Hourly: []HourlyWeather{
+			{Time: 600, Temperature: 283.15, WindSpeed: 2, units: UnitsScientific},
+			{Time: 1200, Temperature: 293.15, WindSpeed: 2, units: UnitsScientific},
+			{Time: 1800, Temperature: 288.15, WindSpeed: 2, units: UnitsScientific},
+		},
+		units: UnitsScientific,
+	}
+
+	bestHour, ok := day.BestHour(BestHourConfig{TargetTemperature: 293.15, TemperatureWeight: 1})
+	if !ok || bestHour.Time != 1200 {
+		t.Errorf("BestHour() = %+v, want the 1200 record", bestHour)
+	}
+
+	if _, ok := (Weather{}).BestHour(BestHourConfig{}); ok {
+		t.Error("BestHour() ok without hourly records")
+	}
+}
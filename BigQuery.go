@@ -0,0 +1,139 @@
+package weatherstack
+
+import (
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// HistoricalWeatherBQ is a day of a HistoricalResponse with types BigQuery schema inference maps to
+// DATE, TIME, FLOAT64, INT64 and STRING, see HistoricalResponse.ToBQ.
+// Astro times are zero when the event does not occur on the date.
+type HistoricalWeatherBQ struct {
+	Location         string     `bigquery:"location"`
+	Lat              float64    `bigquery:"lat"`
+	Lon              float64    `bigquery:"lon"`
+	Date             civil.Date `bigquery:"date"`
+	Units            string     `bigquery:"units"`
+	MinTemp          float64    `bigquery:"min_temp"`
+	MaxTemp          float64    `bigquery:"max_temp"`
+	AvgTemp          float64    `bigquery:"avg_temp"`
+	TotalSnow        float64    `bigquery:"total_snow"`
+	SunHour          float64    `bigquery:"sun_hour"`
+	UVIndex          int64      `bigquery:"uv_index"`
+	Sunrise          civil.Time `bigquery:"sunrise"`
+	Sunset           civil.Time `bigquery:"sunset"`
+	Moonrise         civil.Time `bigquery:"moonrise"`
+	Moonset          civil.Time `bigquery:"moonset"`
+	MoonPhase        string     `bigquery:"moon_phase"`
+	MoonIllumination int64      `bigquery:"moon_illumination"`
+}
+
+// HourlyWeatherBQ is an hourly record of a HistoricalResponse, see HistoricalWeatherBQ
+type HourlyWeatherBQ struct {
+	Location           string     `bigquery:"location"`
+	Date               civil.Date `bigquery:"date"`
+	Time               civil.Time `bigquery:"time"`      // local time
+	Timestamp          time.Time  `bigquery:"timestamp"` // the instant, only set if the location's time zone is known
+	Units              string     `bigquery:"units"`
+	Temperature        float64    `bigquery:"temperature"`
+	FeelsLike          float64    `bigquery:"feels_like"`
+	Dewpoint           float64    `bigquery:"dewpoint"`
+	WindSpeed          float64    `bigquery:"wind_speed"`
+	WindGust           float64    `bigquery:"wind_gust"`
+	WindDegree         int64      `bigquery:"wind_degree"`
+	WindDir            string     `bigquery:"wind_dir"`
+	WeatherCode        int64      `bigquery:"weather_code"`
+	WeatherDescription string     `bigquery:"weather_description"`
+	Precip             float64    `bigquery:"precip"`
+	Humidity           int64      `bigquery:"humidity"`
+	Visibility         float64    `bigquery:"visibility"`
+	Pressure           float64    `bigquery:"pressure"`
+	Cloudcover         int64      `bigquery:"cloudcover"`
+	UVIndex            int64      `bigquery:"uv_index"`
+	ChanceOfRain       int64      `bigquery:"chance_of_rain"`
+	ChanceOfSnow       int64      `bigquery:"chance_of_snow"`
+	ChanceOfThunder    int64      `bigquery:"chance_of_thunder"`
+}
+
+// ToBQ converts the response into daily and hourly rows, both in chronological order.
+// Astro and hourly times that cannot be parsed are left zero, as is Timestamp if the location has no time zone.
+func (r *HistoricalResponse) ToBQ() ([]HistoricalWeatherBQ, []HourlyWeatherBQ) {
+	loc, _ := r.Location.TimeZone()
+
+	days := []HistoricalWeatherBQ{}
+	hours := []HourlyWeatherBQ{}
+
+	for _, day := range r.Days() {
+		date := day.CivilDate()
+
+		days = append(days, HistoricalWeatherBQ{
+			Location:         r.Location.Name,
+			Lat:              float64(r.Location.Lat),
+			Lon:              float64(r.Location.Lon),
+			Date:             date,
+			Units:            r.Request.Unit,
+			MinTemp:          day.MinTemp.Value(),
+			MaxTemp:          day.MaxTemp.Value(),
+			AvgTemp:          day.AvgTemp.Value(),
+			TotalSnow:        day.TotalSnow.Value(),
+			SunHour:          day.SunHour.Value(),
+			UVIndex:          day.UVIndex.Value(),
+			Sunrise:          civilTimeOf(day.Astro.SunriseTime(date, nil)),
+			Sunset:           civilTimeOf(day.Astro.SunsetTime(date, nil)),
+			Moonrise:         civilTimeOf(day.Astro.MoonriseTime(date, nil)),
+			Moonset:          civilTimeOf(day.Astro.MoonsetTime(date, nil)),
+			MoonPhase:        day.Astro.MoonPhase,
+			MoonIllumination: day.Astro.MoonIllumination.Value(),
+		})
+
+		for _, hourly := range day.Hourly {
+			hour := HourlyWeatherBQ{
+				Location:           r.Location.Name,
+				Date:               date,
+				Units:              r.Request.Unit,
+				Temperature:        hourly.Temperature.Value(),
+				FeelsLike:          hourly.FeelsLike.Value(),
+				Dewpoint:           hourly.Dewpoint.Value(),
+				WindSpeed:          hourly.WindSpeed.Value(),
+				WindGust:           hourly.Windgust.Value(),
+				WindDegree:         hourly.WindDegree.Value(),
+				WindDir:            hourly.WindDir,
+				WeatherCode:        int64(hourly.WeatherCode),
+				WeatherDescription: strings.Join(hourly.WeatherDescriptions, ", "),
+				Precip:             hourly.Precip.Value(),
+				Humidity:           hourly.Humidity.Value(),
+				Visibility:         hourly.Visibility.Value(),
+				Pressure:           hourly.Pressure.Value(),
+				Cloudcover:         hourly.Cloudcover.Value(),
+				UVIndex:            hourly.UVIndex.Value(),
+				ChanceOfRain:       hourly.ChanceOfRain.Value(),
+				ChanceOfSnow:       hourly.ChanceOfSnow.Value(),
+				ChanceOfThunder:    hourly.ChanceOfThunder.Value(),
+			}
+
+			if timeOfDay, err := hourly.TimeOfDay(); err == nil {
+				hour.Time = civil.TimeOf(timeOfDay)
+			}
+
+			if loc != nil {
+				if t, err := hourly.TimeOn(date, loc); err == nil {
+					hour.Timestamp = t
+				}
+			}
+
+			hours = append(hours, hour)
+		}
+	}
+
+	return days, hours
+}
+
+func civilTimeOf(t time.Time, err error) civil.Time {
+	if err != nil {
+		return civil.Time{}
+	}
+
+	return civil.TimeOf(t)
+}
@@ -0,0 +1,53 @@
+package weatherstack
+
+import (
+	"net/http"
+	"net/url"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// BuildRequest returns the request the service would send for config, without sending it.
+// config must be a GetCurrentWeatherConfig, GetHistoricalWeatherConfig, GetForecastWeatherConfig or GetMarineWeatherConfig,
+// it is validated and the service defaults are applied as for the actual call.
+// The URL contains the access key, redact it before logging.
+func (service *Service) BuildRequest(config interface{}) (*http.Request, *errortools.Error) {
+	var _url string
+	var e *errortools.Error
+
+	switch config := config.(type) {
+	case GetCurrentWeatherConfig:
+		_url, e = service.currentURL(config)
+	case GetHistoricalWeatherConfig:
+		_url, e = service.historicalURL(config)
+	case GetForecastWeatherConfig:
+		_url, e = service.forecastURL(config)
+	case GetMarineWeatherConfig:
+		_url, e = service.marineURL(config)
+	default:
+		return nil, errortools.ErrorMessagef("Unsupported config type: %T", config)
+	}
+	if e != nil {
+		return nil, e
+	}
+
+	parsedURL, err := url.Parse(_url)
+	if err != nil {
+		return nil, errortools.ErrorMessage(err)
+	}
+
+	query := parsedURL.Query()
+	service.setAccessKey(query)
+	parsedURL.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, errortools.ErrorMessage(err)
+	}
+
+	if service.userAgent != nil {
+		request.Header.Set("User-Agent", *service.userAgent)
+	}
+
+	return request, nil
+}
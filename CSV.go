@@ -0,0 +1,234 @@
+package weatherstack
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+type CSVRows string
+
+const (
+	CSVRowsDaily  CSVRows = "daily"
+	CSVRowsHourly CSVRows = "hourly"
+)
+
+type CSVOptions struct {
+	Rows       CSVRows           // default CSVRowsDaily
+	Columns    []string          // default all columns of Rows, see DailyCSVColumns and HourlyCSVColumns
+	Headers    map[string]string // header per column, default the column name
+	Comma      *rune             // default ','
+	OmitHeader bool
+}
+
+type csvColumn struct {
+	name  string
+	value func(date string, day Weather, hourly HourlyWeather) string
+}
+
+var dailyCSVColumns = []csvColumn{
+	{"date", func(date string, _ Weather, _ HourlyWeather) string { return date }},
+	{"mintemp", func(_ string, day Weather, _ HourlyWeather) string { return formatFloat(day.MinTemp.Value()) }},
+	{"maxtemp", func(_ string, day Weather, _ HourlyWeather) string { return formatFloat(day.MaxTemp.Value()) }},
+	{"avgtemp", func(_ string, day Weather, _ HourlyWeather) string { return formatFloat(day.AvgTemp.Value()) }},
+	{"totalsnow", func(_ string, day Weather, _ HourlyWeather) string { return formatFloat(day.TotalSnow.Value()) }},
+	{"sunhour", func(_ string, day Weather, _ HourlyWeather) string { return formatFloat(day.SunHour.Value()) }},
+	{"uv_index", func(_ string, day Weather, _ HourlyWeather) string { return formatInt(day.UVIndex.Value()) }},
+	{"sunrise", func(_ string, day Weather, _ HourlyWeather) string { return day.Astro.Sunrise.TimeString }},
+	{"sunset", func(_ string, day Weather, _ HourlyWeather) string { return day.Astro.Sunset.TimeString }},
+	{"moonrise", func(_ string, day Weather, _ HourlyWeather) string { return day.Astro.Moonrise.TimeString }},
+	{"moonset", func(_ string, day Weather, _ HourlyWeather) string { return day.Astro.Moonset.TimeString }},
+	{"moon_phase", func(_ string, day Weather, _ HourlyWeather) string { return day.Astro.MoonPhase }},
+	{"moon_illumination", func(_ string, day Weather, _ HourlyWeather) string {
+		return formatInt(day.Astro.MoonIllumination.Value())
+	}},
+}
+
+var hourlyCSVColumns = []csvColumn{
+	{"date", func(date string, _ Weather, _ HourlyWeather) string { return date }},
+	{"time", func(_ string, _ Weather, h HourlyWeather) string { return formatInt(int64(h.Time)) }},
+	{"temperature", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.Temperature.Value()) }},
+	{"wind_speed", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.WindSpeed.Value()) }},
+	{"wind_degree", func(_ string, _ Weather, h HourlyWeather) string { return formatInt(h.WindDegree.Value()) }},
+	{"wind_dir", func(_ string, _ Weather, h HourlyWeather) string { return h.WindDir }},
+	{"weather_code", func(_ string, _ Weather, h HourlyWeather) string { return formatInt(int64(h.WeatherCode)) }},
+	{"weather_descriptions", func(_ string, _ Weather, h HourlyWeather) string { return strings.Join(h.WeatherDescriptions, ", ") }},
+	{"precip", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.Precip.Value()) }},
+	{"humidity", func(_ string, _ Weather, h HourlyWeather) string { return formatInt(h.Humidity.Value()) }},
+	{"visibility", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.Visibility.Value()) }},
+	{"pressure", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.Pressure.Value()) }},
+	{"cloudcover", func(_ string, _ Weather, h HourlyWeather) string { return formatInt(h.Cloudcover.Value()) }},
+	{"heatindex", func(_ string, _ Weather, h HourlyWeather) string { return formatNullFloat(h.Heatindex) }},
+	{"dewpoint", func(_ string, _ Weather, h HourlyWeather) string { return formatNullFloat(h.Dewpoint) }},
+	{"windchill", func(_ string, _ Weather, h HourlyWeather) string { return formatNullFloat(h.Windchill) }},
+	{"windgust", func(_ string, _ Weather, h HourlyWeather) string { return formatNullFloat(h.Windgust) }},
+	{"feelslike", func(_ string, _ Weather, h HourlyWeather) string { return formatFloat(h.FeelsLike.Value()) }},
+	{"chanceofrain", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfRain) }},
+	{"chanceofremdry", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfRemDry) }},
+	{"chanceofwindy", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfWindy) }},
+	{"chanceofovercast", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfOvercast) }},
+	{"chanceofsunshine", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfSunshine) }},
+	{"chanceoffrost", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfFrost) }},
+	{"chanceofhightemp", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfHighTemp) }},
+	{"chanceoffog", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfFog) }},
+	{"chanceofsnow", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfSnow) }},
+	{"chanceofthunder", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.ChanceOfThunder) }},
+	{"uv_index", func(_ string, _ Weather, h HourlyWeather) string { return formatNullInt(h.UVIndex) }},
+}
+
+// DailyCSVColumns returns the columns available for CSVRowsDaily, in their default order
+func DailyCSVColumns() []string {
+	return csvColumnNames(dailyCSVColumns)
+}
+
+// HourlyCSVColumns returns the columns available for CSVRowsHourly, in their default order
+func HourlyCSVColumns() []string {
+	return csvColumnNames(hourlyCSVColumns)
+}
+
+// WriteCSV writes the historical days, or their hourly records, as CSV in chronological order
+func (r *HistoricalResponse) WriteCSV(w io.Writer, opts CSVOptions) *errortools.Error {
+	return writeCSV(w, r.Historical, opts)
+}
+
+// WriteCSV writes the forecast days, or their hourly records, as CSV, see HistoricalResponse.WriteCSV
+func (r *ForecastResponse) WriteCSV(w io.Writer, opts CSVOptions) *errortools.Error {
+	return writeCSV(w, r.Forecast, opts)
+}
+
+func writeCSV(w io.Writer, days map[string]Weather, opts CSVOptions) *errortools.Error {
+	var available []csvColumn
+
+	switch opts.Rows {
+	case "", CSVRowsDaily:
+		available = dailyCSVColumns
+	case CSVRowsHourly:
+		available = hourlyCSVColumns
+	default:
+		return errortools.ErrorMessagef("Invalid CSV rows: %s", opts.Rows)
+	}
+
+	columns := available
+	if len(opts.Columns) > 0 {
+		columns = make([]csvColumn, len(opts.Columns))
+		for i, name := range opts.Columns {
+			column, ok := findCSVColumn(available, name)
+			if !ok {
+				return errortools.ErrorMessagef("Unknown %s CSV column: %s", opts.Rows, name)
+			}
+			columns[i] = column
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Comma != nil {
+		writer.Comma = *opts.Comma
+	}
+
+	if !opts.OmitHeader {
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.name
+			if name, ok := opts.Headers[column.name]; ok {
+				header[i] = name
+			}
+		}
+
+		if err := writer.Write(header); err != nil {
+			return errortools.ErrorMessage(err)
+		}
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	writeRow := func(date string, day Weather, hourly HourlyWeather) *errortools.Error {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.value(date, day, hourly)
+		}
+
+		if err := writer.Write(row); err != nil {
+			return errortools.ErrorMessage(err)
+		}
+
+		return nil
+	}
+
+	for _, date := range dates {
+		day := days[date]
+
+		if opts.Rows == CSVRowsHourly {
+			for _, hourly := range day.Hourly {
+				if e := writeRow(date, day, hourly); e != nil {
+					return e
+				}
+			}
+			continue
+		}
+
+		if e := writeRow(date, day, HourlyWeather{}); e != nil {
+			return e
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	return nil
+}
+
+func findCSVColumn(columns []csvColumn, name string) (csvColumn, bool) {
+	for _, column := range columns {
+		if column.name == name {
+			return column, true
+		}
+	}
+
+	return csvColumn{}, false
+}
+
+func csvColumnNames(columns []csvColumn) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+
+	return names
+}
+
+func formatInt(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+// formatNullInt returns an empty string for an absent value
+func formatNullInt(i w_types.NullInt64OrString) string {
+	if !i.Valid {
+		return ""
+	}
+
+	return formatInt(i.Value())
+}
+
+// formatNullFloat returns an empty string for an absent value
+func formatNullFloat(f w_types.NullFloat64OrString) string {
+	if !f.Valid {
+		return ""
+	}
+
+	return formatFloat(f.Value())
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,161 @@
+package weatherstack
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// Cache stores raw response bodies, keyed by HTTP method and request URL (without access key),
+// the URL containing the endpoint, query, dates, units and all other parameters.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration) // a ttl of 0 never expires
+}
+
+// ErrCacheMiss is returned in offline mode for requests not in the cache
+var ErrCacheMiss = errors.New("response not cached, not requested in offline mode")
+
+type CacheConfig struct {
+	TTL     time.Duration // time to live of cached responses, 0 never expires, historical responses for past dates never expire
+	Store   Cache         // defaults to a new MemoryCache, see DiskCache for a cache persisting between runs
+	Offline bool          // serve exclusively from the cache, failing with ErrCacheMiss instead of sending requests
+}
+
+// MemoryCache is an in-memory Cache, values being copied on Set and Get so callers cannot modify the cached bytes
+type MemoryCache struct {
+	mutex   sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   []byte
+	expires *time.Time // nil for entries that never expire
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (cache *MemoryCache) Get(key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if entry.expires != nil && time.Now().After(*entry.expires) {
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	return append([]byte(nil), entry.value...), true
+}
+
+func (cache *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	entry := cacheEntry{
+		value: append([]byte(nil), value...),
+	}
+	if ttl > 0 {
+		expires := time.Now().Add(ttl)
+		entry.expires = &expires
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries[key] = entry
+}
+
+// Clear removes all entries
+func (cache *MemoryCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries = make(map[string]cacheEntry)
+}
+
+// responseCache is the Cache of a service along with its TTL.
+// A nil *responseCache is a disabled cache.
+type responseCache struct {
+	store   Cache
+	ttl     time.Duration
+	offline bool
+}
+
+func newResponseCache(config *CacheConfig) *responseCache {
+	if config == nil {
+		return nil
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewMemoryCache()
+	}
+
+	return &responseCache{
+		store:   store,
+		ttl:     config.TTL,
+		offline: config.Offline,
+	}
+}
+
+func (cache *responseCache) get(key string) ([]byte, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	return cache.store.Get(key)
+}
+
+func (cache *responseCache) isOffline() bool {
+	return cache != nil && cache.offline
+}
+
+func (cache *responseCache) set(key string, _url *url.URL, rawResponse []byte) {
+	if cache == nil {
+		return
+	}
+
+	cache.store.Set(key, rawResponse, cacheTTL(_url, cache.ttl))
+}
+
+// ClearCache removes all cached responses, provided the Cache has a Clear method as MemoryCache has
+func (service *Service) ClearCache() {
+	if service.cache == nil {
+		return
+	}
+
+	if clearer, ok := service.cache.store.(interface{ Clear() }); ok {
+		clearer.Clear()
+	}
+}
+
+// cacheTTL returns 0 (never expire) for historical requests ending before yesterday (UTC),
+// since these are immutable, and ttl otherwise
+func cacheTTL(_url *url.URL, ttl time.Duration) time.Duration {
+	if strings.HasSuffix(_url.Path, "/historical") {
+		query := _url.Query()
+
+		endDate := query.Get("historical_date_end")
+		if endDate == "" {
+			endDate = query.Get("historical_date")
+		}
+
+		date, err := civil.ParseDate(endDate)
+		if err == nil && date.Before(civil.DateOf(time.Now().UTC()).AddDays(-1)) {
+			return 0
+		}
+	}
+
+	return ttl
+}
@@ -0,0 +1,67 @@
+package weatherstack
+
+import (
+	"net/http"
+	"testing"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestMemoryCacheCopiesValues(t *testing.T) {
+	cache := NewMemoryCache()
+
+	value := []byte("response")
+	cache.Set("key", value, 0)
+	value[0] = 'X'
+
+	got, ok := cache.Get("key")
+	if !ok || string(got) != "response" {
+		t.Fatalf("Get() = %q, %v after modifying the value set", got, ok)
+	}
+
+	got[0] = 'X'
+	if again, _ := cache.Get("key"); string(again) != "response" {
+		t.Errorf("Get() = %q after modifying the value returned", again)
+	}
+}
+
+func TestRawResponseDoesNotAliasCache(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"request":{"query":"Amsterdam, Netherlands"},"location":{"name":"Amsterdam"},"current":{"weather_descriptions":["Sunny"]}}`))
+	}, ServiceConfig{Cache: &CacheConfig{}, IncludeRawResponse: true})
+
+	first, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+	if e != nil {
+		t.Fatal(e.Message())
+	}
+	for i := range first.RawResponse {
+		first.RawResponse[i] = ' '
+	}
+
+	second, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+	if e != nil {
+		t.Fatalf("cached response corrupted: %s", e.Message())
+	}
+	if second.Location.Name != "Amsterdam" {
+		t.Errorf("Location.Name = %q", second.Location.Name)
+	}
+}
+
+func TestHollowResponseIsNotCached(t *testing.T) {
+	requests := 0
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"request":{"query":"Amsterdam, Netherlands"},"location":{"name":"Amsterdam"},"historical":{}}`))
+	}, ServiceConfig{Cache: &CacheConfig{}})
+
+	config := GetHistoricalWeatherConfig{Query: "Amsterdam", StartDate: civil.Date{Year: 2020, Month: 1, Day: 1}}
+	for i := 0; i < 2; i++ {
+		if _, e := service.GetHistoricalWeather(config); e == nil {
+			t.Fatal("GetHistoricalWeather() accepted a response without historical weather")
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("got %v requests, want 2 since the hollow response must not be cached", requests)
+	}
+}
@@ -0,0 +1,120 @@
+package weatherstack
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is the error with which requests are refused while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitBreakerConfig configures failing fast during outages. Only failures that are retryable
+// (network errors, 5xx and 429 responses) count, Weatherstack API errors and ErrFixtureNotFound do not.
+// Once open, a single probe request is let through after OpenDuration, closing the breaker if it succeeds.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // consecutive failed requests (after retries) that open the breaker, defaults to 5
+	OpenDuration     time.Duration // defaults to 30 seconds
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is shared by all requests of a service, a nil *circuitBreaker never opens
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	mutex            sync.Mutex
+	state            circuitState
+	failures         int
+	openedAt         time.Time
+	probing          bool
+}
+
+func newCircuitBreaker(config *CircuitBreakerConfig) *circuitBreaker {
+	if config == nil {
+		return nil
+	}
+
+	breaker := circuitBreaker{
+		failureThreshold: 5,
+		openDuration:     30 * time.Second,
+	}
+	if config.FailureThreshold > 0 {
+		breaker.failureThreshold = config.FailureThreshold
+	}
+	if config.OpenDuration > 0 {
+		breaker.openDuration = config.OpenDuration
+	}
+
+	return &breaker
+}
+
+// allow reports whether a request may be sent, each allowed request must be followed by done or release
+func (breaker *circuitBreaker) allow() bool {
+	if breaker == nil {
+		return true
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case circuitOpen:
+		if time.Since(breaker.openedAt) < breaker.openDuration {
+			return false
+		}
+		breaker.state = circuitHalfOpen
+	case circuitClosed:
+		return true
+	}
+
+	if breaker.probing {
+		return false
+	}
+	breaker.probing = true
+
+	return true
+}
+
+// done records the outcome of an allowed request, available being false for a failure that counts
+func (breaker *circuitBreaker) done(available bool) {
+	if breaker == nil {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	wasProbe := breaker.state == circuitHalfOpen
+	breaker.probing = false
+
+	if available {
+		breaker.state = circuitClosed
+		breaker.failures = 0
+		return
+	}
+
+	breaker.failures++
+	if wasProbe || breaker.failures >= breaker.failureThreshold {
+		breaker.state = circuitOpen
+		breaker.openedAt = time.Now()
+	}
+}
+
+// release ends an allowed request whose outcome says nothing about the API, e.g. because its context was canceled
+func (breaker *circuitBreaker) release() {
+	if breaker == nil {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probing = false
+}
@@ -0,0 +1,181 @@
+package weatherstack
+
+import (
+	"context"
+	"sort"
+
+	"cloud.google.com/go/civil"
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// Metric is a daily value that can be compared across locations
+type Metric string
+
+const (
+	MetricMinTemp   Metric = "mintemp"
+	MetricMaxTemp   Metric = "maxtemp"
+	MetricAvgTemp   Metric = "avgtemp"
+	MetricTotalSnow Metric = "totalsnow"
+	MetricSunHour   Metric = "sunhour"
+	MetricUVIndex   Metric = "uv_index"
+	MetricPrecip    Metric = "precip" // see PrecipFromHourly
+)
+
+var metricValues = map[Metric]func(day Weather) (float64, bool){
+	MetricMinTemp:   func(day Weather) (float64, bool) { return day.MinTemp.Value(), true },
+	MetricMaxTemp:   func(day Weather) (float64, bool) { return day.MaxTemp.Value(), true },
+	MetricAvgTemp:   func(day Weather) (float64, bool) { return day.AvgTemp.Value(), true },
+	MetricTotalSnow: func(day Weather) (float64, bool) { return day.TotalSnow.Value(), true },
+	MetricSunHour:   func(day Weather) (float64, bool) { return day.SunHour.Value(), true },
+	MetricUVIndex:   func(day Weather) (float64, bool) { return float64(day.UVIndex.Value()), true },
+	MetricPrecip:    func(day Weather) (float64, bool) { return day.PrecipFromHourly() },
+}
+
+// Comparison aligns daily metrics of several locations by date
+type Comparison struct {
+	Dates     []civil.Date // all dates present for any location, in chronological order
+	Locations []string
+	Values    map[Metric][][]*float64 // indexed by date, then location; nil where a location has no (value for the) date
+}
+
+// Value returns the value of metric for location on date, ok is false if it is missing
+func (c Comparison) Value(metric Metric, date civil.Date, location string) (float64, bool) {
+	dateIndex := sort.Search(len(c.Dates), func(i int) bool {
+		return !c.Dates[i].Before(date)
+	})
+	if dateIndex == len(c.Dates) || c.Dates[dateIndex] != date {
+		return 0, false
+	}
+
+	for locationIndex, l := range c.Locations {
+		if l == location {
+			value := c.Values[metric][dateIndex][locationIndex]
+			if value == nil {
+				return 0, false
+			}
+			return *value, true
+		}
+	}
+
+	return 0, false
+}
+
+type CompareLocationsConfig struct {
+	Queries []string
+	Config  GetHistoricalWeatherConfig // Query is ignored, the range may exceed MaxDaysPerCall days
+	Metrics []Metric
+}
+
+// CompareLocations requests the historical weather for each query sequentially and aligns the metrics, see Compare.
+// Failed queries are returned keyed by query, their location has no values in the comparison. The errors are nil if no query failed.
+func (service *Service) CompareLocations(config CompareLocationsConfig) (*Comparison, map[string]*RequestError) {
+	return service.CompareLocationsWithContext(context.Background(), config)
+}
+
+func (service *Service) CompareLocationsWithContext(ctx context.Context, config CompareLocationsConfig) (*Comparison, map[string]*RequestError) {
+	errs := make(map[string]*RequestError)
+
+	if e := validateMetrics(config.Metrics); e != nil {
+		for _, query := range config.Queries {
+			errs[query] = newRequestError(e, nil)
+		}
+		return nil, errs
+	}
+
+	responses := make(map[string]*HistoricalResponse, len(config.Queries))
+
+	for _, query := range config.Queries {
+		historicalConfig := config.Config
+		historicalConfig.Query = query
+
+		historicalResponse, e := service.GetHistoricalWeatherRangeWithContext(ctx, historicalConfig)
+		if e != nil {
+			errs[query] = e
+			continue
+		}
+
+		responses[query] = historicalResponse
+	}
+
+	comparison, _ := Compare(config.Queries, responses, config.Metrics)
+
+	if len(errs) == 0 {
+		return comparison, nil
+	}
+
+	return comparison, errs
+}
+
+// Compare aligns the metrics of previously fetched responses, keyed by location.
+// locations determines the order of the locations, locations without response have no values.
+func Compare(locations []string, responses map[string]*HistoricalResponse, metrics []Metric) (*Comparison, *errortools.Error) {
+	if e := validateMetrics(metrics); e != nil {
+		return nil, e
+	}
+
+	dateSet := make(map[civil.Date]bool)
+	for _, location := range locations {
+		if response := responses[location]; response != nil {
+			for key := range response.Historical {
+				if date, err := civil.ParseDate(key); err == nil {
+					dateSet[date] = true
+				}
+			}
+		}
+	}
+
+	comparison := Comparison{
+		Dates:     make([]civil.Date, 0, len(dateSet)),
+		Locations: locations,
+		Values:    make(map[Metric][][]*float64, len(metrics)),
+	}
+
+	for date := range dateSet {
+		comparison.Dates = append(comparison.Dates, date)
+	}
+	sort.Slice(comparison.Dates, func(i, j int) bool {
+		return comparison.Dates[i].Before(comparison.Dates[j])
+	})
+
+	for _, metric := range metrics {
+		matrix := make([][]*float64, len(comparison.Dates))
+
+		for dateIndex, date := range comparison.Dates {
+			matrix[dateIndex] = make([]*float64, len(locations))
+
+			for locationIndex, location := range locations {
+				response := responses[location]
+				if response == nil {
+					continue
+				}
+
+				day, ok := response.Historical[date.String()]
+				if !ok {
+					continue
+				}
+
+				if value, ok := metricValues[metric](day); ok {
+					matrix[dateIndex][locationIndex] = &value
+				}
+			}
+		}
+
+		comparison.Values[metric] = matrix
+	}
+
+	return &comparison, nil
+}
+
+func validateMetrics(metrics []Metric) *errortools.Error {
+	if len(metrics) == 0 {
+		return errortools.ErrorMessage("No Metrics provided")
+	}
+
+	for _, metric := range metrics {
+		if _, ok := metricValues[metric]; !ok {
+			return errortools.ErrorMessagef("Unknown Metric: %s", string(metric))
+		}
+	}
+
+	return nil
+}
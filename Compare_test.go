@@ -0,0 +1,41 @@
+package weatherstack
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestCompareLocationsErrors(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/historical_scientific.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("query"), "Nowhere") {
+			w.Write([]byte(`{"success":false,"error":{"code":615,"type":"request_failed"}}`))
+			return
+		}
+		w.Write(body)
+	}, ServiceConfig{})
+
+	startDate := civil.Date{Year: 2021, Month: 9, Day: 9}
+	config := CompareLocationsConfig{
+		Queries: []string{"Amsterdam"},
+		Config:  GetHistoricalWeatherConfig{StartDate: startDate},
+		Metrics: []Metric{MetricAvgTemp},
+	}
+
+	comparison, errs := service.CompareLocations(config)
+	if comparison == nil || errs != nil {
+		t.Errorf("CompareLocations() = %v, %v, want a comparison and nil errors", comparison, errs)
+	}
+
+	config.Queries = append(config.Queries, "Nowhere")
+	if _, errs = service.CompareLocations(config); len(errs) != 1 || errs["Nowhere"] == nil {
+		t.Errorf("CompareLocations() errors = %v, want an error for Nowhere", errs)
+	}
+}
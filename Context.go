@@ -0,0 +1,60 @@
+package weatherstack
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// contextTransport binds the requests it sends to a context, since go_http does not accept one
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+	err  error // of the last request, since go_http only keeps its message
+}
+
+func (transport *contextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := transport.base.RoundTrip(request.WithContext(transport.ctx))
+	transport.err = err
+
+	return response, err
+}
+
+// httpClientWithContext returns a copy of the service's http client whose requests are bound to ctx
+func (service *Service) httpClientWithContext(ctx context.Context) *http.Client {
+	httpClient := *service.httpClient
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	httpClient.Transport = &contextTransport{
+		ctx:  ctx,
+		base: base,
+	}
+
+	return &httpClient
+}
+
+// userAgentTransport sets the User-Agent header of the requests it sends
+type userAgentTransport struct {
+	userAgent string
+	base      http.RoundTripper
+}
+
+func (transport *userAgentTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("User-Agent", transport.userAgent)
+
+	return transport.base.RoundTrip(request)
+}
+
+// withTimeout derives a context with timeout from ctx, if timeout is set
+func withTimeout(ctx context.Context, timeout *time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == nil {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, *timeout)
+}
@@ -0,0 +1,52 @@
+package weatherstack
+
+// temperatureToCelsius converts a temperature returned by the API in the given units to degrees Celsius
+func temperatureToCelsius(value float64, units Units) float64 {
+	switch units {
+	case UnitsScientific:
+		return value - 273.15
+	case UnitsFahrenheit:
+		return (value - 32) * 5 / 9
+	}
+
+	return value
+}
+
+// temperatureFromCelsius converts a temperature in degrees Celsius to the given units
+func temperatureFromCelsius(value float64, units Units) float64 {
+	switch units {
+	case UnitsScientific:
+		return value + 273.15
+	case UnitsFahrenheit:
+		return value*9/5 + 32
+	}
+
+	return value
+}
+
+// speedToKmH converts a wind speed returned by the API in the given units to km/h
+func speedToKmH(value float64, units Units) float64 {
+	if units == UnitsFahrenheit {
+		return value * 1.609344
+	}
+
+	return value
+}
+
+// speedFromKmH converts a wind speed in km/h to the given units
+func speedFromKmH(value float64, units Units) float64 {
+	if units == UnitsFahrenheit {
+		return value / 1.609344
+	}
+
+	return value
+}
+
+// distanceToKm converts a distance (visibility) returned by the API in the given units to km
+func distanceToKm(value float64, units Units) float64 {
+	if units == UnitsFahrenheit {
+		return value * 1.609344
+	}
+
+	return value
+}
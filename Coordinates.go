@@ -0,0 +1,74 @@
+package weatherstack
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// Coordinates can be passed to the endpoint configs instead of a free-text Query
+type Coordinates struct {
+	Lat float64
+	Lon float64
+}
+
+func (c Coordinates) Validate() *errortools.Error {
+	if math.IsNaN(c.Lat) || math.IsNaN(c.Lon) {
+		return errortools.ErrorMessage("Coordinates must not be NaN")
+	}
+
+	if c.Lat < -90 || c.Lat > 90 {
+		return errortools.ErrorMessagef("Latitude %v out of range [-90,90]", c.Lat)
+	}
+
+	if c.Lon < -180 || c.Lon > 180 {
+		return errortools.ErrorMessagef("Longitude %v out of range [-180,180]", c.Lon)
+	}
+
+	return nil
+}
+
+// Query returns the coordinates formatted as "lat,lon" with at most 6 decimals (~0.1 m)
+func (c Coordinates) Query() string {
+	return coordinatesQuery(c.Lat, c.Lon)
+}
+
+// coordinatesQuery formats coordinates as a "lat,lon" query with at most 6 decimals, never in scientific notation
+func coordinatesQuery(lat float64, lon float64) string {
+	format := func(f float64) string {
+		s := strings.TrimRight(strconv.FormatFloat(f, 'f', 6, 64), "0")
+		s = strings.TrimSuffix(s, ".")
+		if s == "-0" {
+			return "0"
+		}
+		return s
+	}
+
+	return fmt.Sprintf("%s,%s", format(lat), format(lon))
+}
+
+// resolveQuery returns the query parameter value from either a free-text query or coordinates
+func resolveQuery(query string, coordinates *Coordinates) (string, *errortools.Error) {
+	if coordinates == nil {
+		e := validateQuery(query)
+		if e != nil {
+			return "", e
+		}
+
+		return query, nil
+	}
+
+	if query != "" {
+		return "", errortools.ErrorMessage("Query and Coordinates must not both be set")
+	}
+
+	e := coordinates.Validate()
+	if e != nil {
+		return "", e
+	}
+
+	return coordinates.Query(), nil
+}
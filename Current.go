@@ -0,0 +1,123 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	go_http "github.com/leapforce-libraries/go_http"
+)
+
+type CurrentResponse struct {
+	Request     Request        `json:"request"`
+	Location    Location       `json:"location"`
+	Current     CurrentWeather `json:"current"`
+	RawResponse []byte         `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true
+	Meta        *ResponseMeta  `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true and the response was not cached
+}
+
+type GetCurrentWeatherConfig struct {
+	Query           string
+	Coordinates     *Coordinates // alternative to Query
+	Units           *Units
+	Language        *Language
+	BaseURLOverride *string
+	ExtraParams     url.Values     // merged into the query string, overriding parameters with the same name
+	Timeout         *time.Duration // of the call including retries, in addition to the deadline of the context
+}
+
+func (service *Service) GetCurrentWeather(config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError) {
+	return service.GetCurrentWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetCurrentWeatherWithContext(ctx context.Context, config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError) {
+	currentResponse, _, e := service.getCurrentWeather(ctx, config)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := currentResponse.Validate(); e != nil {
+		return nil, newRequestError(e, nil)
+	}
+
+	service.locations.remember(config.Query, currentResponse.Location)
+
+	return currentResponse, nil
+}
+
+// Validate checks the config locally, returning all violations at once
+func (config GetCurrentWeatherConfig) Validate() *errortools.Error {
+	var v violations
+
+	v.add(validateQueryOrCoordinates(config.Query, config.Coordinates))
+	v.add(validateTimeout(config.Timeout))
+	v.add(validateParameters(config.Units, nil, config.Language))
+
+	return v.error()
+}
+
+// getCurrentWeather also returns the error object returned by Weatherstack, if any
+func (service *Service) getCurrentWeather(ctx context.Context, config GetCurrentWeatherConfig) (*CurrentResponse, *WeatherstackError, *RequestError) {
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	_url, e := service.currentURL(config)
+	if e != nil {
+		return nil, nil, newRequestError(e, nil)
+	}
+
+	currentResponse := CurrentResponse{}
+
+	requestConfig := go_http.RequestConfig{
+		URL:           _url,
+		ResponseModel: &currentResponse,
+	}
+
+	_, _, requestError := service.get(ctx, &requestConfig)
+	if requestError != nil {
+		return nil, weatherstackError(&requestConfig), requestError
+	}
+
+	return &currentResponse, nil, nil
+}
+
+func (service *Service) currentURL(config GetCurrentWeatherConfig) (string, *errortools.Error) {
+	config.Query, config.Coordinates = service.locations.rewrite(config.Query, config.Coordinates)
+	config.Units = service.unitsOrDefault(config.Units)
+	config.Language = service.languageOrDefault(config.Language)
+
+	e := config.Validate()
+	if e != nil {
+		return "", e
+	}
+
+	query, e := resolveQuery(config.Query, config.Coordinates)
+	if e != nil {
+		return "", e
+	}
+
+	values := baseValues(query, config.Units, nil, nil, config.Language)
+
+	mergeExtraParams(values, config.ExtraParams)
+
+	return service.urlWithOverride(config.BaseURLOverride, fmt.Sprintf("current?%s", values.Encode()))
+}
+
+// GetCurrentWeatherForLocation requests the current weather for the coordinates of location, config.Query and config.Coordinates are ignored
+func (service *Service) GetCurrentWeatherForLocation(location Location, config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError) {
+	return service.GetCurrentWeatherForLocationWithContext(context.Background(), location, config)
+}
+
+func (service *Service) GetCurrentWeatherForLocationWithContext(ctx context.Context, location Location, config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError) {
+	lat, lon, err := location.Coordinates()
+	if err != nil {
+		return nil, newRequestError(errortools.ErrorMessage(err), nil)
+	}
+
+	config.Query = ""
+	config.Coordinates = &Coordinates{Lat: lat, Lon: lon}
+
+	return service.GetCurrentWeatherWithContext(ctx, config)
+}
@@ -0,0 +1,55 @@
+package weatherstack
+
+import "context"
+
+// WeatherDiff holds the change between two current weather readings, expressed in the units of the most recent reading
+type WeatherDiff struct {
+	HasPrior         bool
+	Temperature      float64
+	FeelsLike        float64
+	Pressure         float64
+	WindSpeed        float64
+	Humidity         float64
+	ConditionChanged bool
+}
+
+// GetCurrentWeatherDiff fetches the current weather and compares it to a prior reading, which may be nil
+func (service *Service) GetCurrentWeatherDiff(config GetCurrentWeatherConfig, prior *CurrentResponse) (*CurrentResponse, WeatherDiff, *RequestError) {
+	return service.GetCurrentWeatherDiffWithContext(context.Background(), config, prior)
+}
+
+func (service *Service) GetCurrentWeatherDiffWithContext(ctx context.Context, config GetCurrentWeatherConfig, prior *CurrentResponse) (*CurrentResponse, WeatherDiff, *RequestError) {
+	currentResponse, e := service.GetCurrentWeatherWithContext(ctx, config)
+	if e != nil {
+		return nil, WeatherDiff{}, e
+	}
+
+	return currentResponse, currentResponse.Diff(prior), nil
+}
+
+// Diff computes the change since a prior reading, converting the prior reading to the units of r when they differ
+func (r *CurrentResponse) Diff(prior *CurrentResponse) WeatherDiff {
+	if prior == nil {
+		return WeatherDiff{}
+	}
+
+	units := Units(r.Request.Unit)
+	priorUnits := Units(prior.Request.Unit)
+
+	temperature := func(value float64) float64 {
+		return temperatureFromCelsius(temperatureToCelsius(value, priorUnits), units)
+	}
+	speed := func(value float64) float64 {
+		return speedFromKmH(speedToKmH(value, priorUnits), units)
+	}
+
+	return WeatherDiff{
+		HasPrior:         true,
+		Temperature:      r.Current.Temperature.Value() - temperature(prior.Current.Temperature.Value()),
+		FeelsLike:        r.Current.FeelsLike.Value() - temperature(prior.Current.FeelsLike.Value()),
+		Pressure:         r.Current.Pressure.Value() - prior.Current.Pressure.Value(),
+		WindSpeed:        r.Current.WindSpeed.Value() - speed(prior.Current.WindSpeed.Value()),
+		Humidity:         float64(r.Current.Humidity.Value() - prior.Current.Humidity.Value()),
+		ConditionChanged: r.Current.WeatherCode != prior.Current.WeatherCode,
+	}
+}
@@ -0,0 +1,37 @@
+package weatherstack
+
+import (
+	"context"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// GetCurrentWeatherWithFallback requests the current weather for config.Query and, as long as the location is not found,
+// retries with each of the fallback queries in order. The query that succeeded is returned along with the response.
+// Location not found is detected by Weatherstack error 615 (request failed) or an empty location in the response.
+// Errors other than location not found are returned immediately.
+func (service *Service) GetCurrentWeatherWithFallback(config GetCurrentWeatherConfig, fallbackQueries []string) (*CurrentResponse, string, *RequestError) {
+	return service.GetCurrentWeatherWithFallbackWithContext(context.Background(), config, fallbackQueries)
+}
+
+func (service *Service) GetCurrentWeatherWithFallbackWithContext(ctx context.Context, config GetCurrentWeatherConfig, fallbackQueries []string) (*CurrentResponse, string, *RequestError) {
+	queries := append([]string{config.Query}, fallbackQueries...)
+
+	for _, query := range queries {
+		config.Query = query
+
+		currentResponse, apiError, e := service.getCurrentWeather(ctx, config)
+		if apiError != nil && apiError.Code == ErrorCodeRequestFailed {
+			continue
+		}
+		if e != nil {
+			return nil, "", e
+		}
+
+		if currentResponse.Location.Name != "" {
+			return currentResponse, query, nil
+		}
+	}
+
+	return nil, "", newRequestError(errortools.ErrorMessagef("No location found for query %s or its fallbacks", queries[0]), nil)
+}
@@ -0,0 +1,62 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// CurrentWeatherResult is the outcome of a single query of GetCurrentWeatherMany
+type CurrentWeatherResult struct {
+	Query    string
+	Response *CurrentResponse // nil if Error is set
+	Error    *RequestError
+}
+
+// GetCurrentWeatherMany requests the current weather for each query, using config for the other parameters,
+// through a pool of options.Concurrency workers. The results are aligned to queries. Failed queries do not fail the batch
+// (unless options.StopOnError is set), the returned error lists all failures and is nil if all queries succeeded.
+func (service *Service) GetCurrentWeatherMany(queries []string, config GetCurrentWeatherConfig, options BatchOptions) ([]CurrentWeatherResult, *RequestError) {
+	return service.GetCurrentWeatherManyWithContext(context.Background(), queries, config, options)
+}
+
+func (service *Service) GetCurrentWeatherManyWithContext(ctx context.Context, queries []string, config GetCurrentWeatherConfig, options BatchOptions) ([]CurrentWeatherResult, *RequestError) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	config.Coordinates = nil
+
+	results := make([]CurrentWeatherResult, len(queries))
+
+	throttle, stop := newThrottle(options.RequestsPerSecond)
+	defer stop()
+
+	runConcurrently(len(queries), options.Concurrency, func(i int) {
+		throttle(ctx)
+
+		queryConfig := config
+		queryConfig.Query = queries[i]
+
+		currentResponse, e := service.GetCurrentWeatherWithContext(ctx, queryConfig)
+
+		results[i] = CurrentWeatherResult{Query: queries[i], Response: currentResponse, Error: e}
+		if e != nil && options.StopOnError {
+			cancel()
+		}
+	})
+
+	failures := []string{}
+	for _, result := range results {
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Query, result.Error.Message()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return results, newRequestError(errortools.ErrorMessagef("%v of %v queries failed: %s", len(failures), len(queries), strings.Join(failures, "; ")), nil)
+	}
+
+	return results, nil
+}
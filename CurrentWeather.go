@@ -1,24 +1,49 @@
 package weatherstack
 
 import (
+	"strings"
+
 	w_types "github.com/leapforce-libraries/go_weatherstack/types"
 )
 
 type CurrentWeather struct {
-	ObservationTime     w_types.TimeString `json:"observation_time"`
-	Temperature         int64              `json:"temperature"`
-	WeatherCode         int64              `json:"weather_code"`
-	WeatherIcons        []string           `json:"weather_icons"`
-	WeatherDescriptions []string           `json:"weather_descriptions"`
-	WindSpeed           int64              `json:"wind_speed"`
-	WindDegree          int64              `json:"wind_degree"`
-	WindDir             string             `json:"wind_dir"`
-	Pressure            int64              `json:"pressure"`
-	Precip              float64            `json:"precip"`
-	Humidity            int64              `json:"humidity"`
-	Cloudcover          int64              `json:"cloudcover"`
-	FeelsLike           int64              `json:"feelslike"`
-	UVIndex             int64              `json:"uv_index"`
-	Visibility          int64              `json:"visibility"`
-	IsDay               string             `json:"is_day"`
+	ObservationTime     w_types.TimeString      `json:"observation_time"`
+	Temperature         w_types.Float64OrString `json:"temperature"`
+	WeatherCode         WeatherCode             `json:"weather_code"`
+	WeatherIcons        []string                `json:"weather_icons"`
+	WeatherDescriptions []string                `json:"weather_descriptions"`
+	WindSpeed           w_types.Float64OrString `json:"wind_speed"`
+	WindDegree          w_types.Int64OrString   `json:"wind_degree"`
+	WindDir             string                  `json:"wind_dir"`
+	Pressure            w_types.Float64OrString `json:"pressure"`
+	Precip              w_types.Float64OrString `json:"precip"`
+	Humidity            w_types.Int64OrString   `json:"humidity"`
+	Cloudcover          w_types.Int64OrString   `json:"cloudcover"`
+	FeelsLike           w_types.Float64OrString `json:"feelslike"`
+	UVIndex             w_types.Int64OrString   `json:"uv_index"`
+	Visibility          w_types.Float64OrString `json:"visibility"`
+	IsDay               w_types.YesNoString     `json:"is_day"`
+	units               Units                   // units of the request, set when decoding the response
+}
+
+// PrimaryIcon returns the weather icon url matching IsDay.
+// Night icons are recognized by "night" in their url, if only one icon is provided it is returned as is.
+func (c CurrentWeather) PrimaryIcon() string {
+	if len(c.WeatherIcons) == 0 {
+		return ""
+	}
+
+	if len(c.WeatherIcons) == 1 {
+		return c.WeatherIcons[0]
+	}
+
+	night := !c.IsDay.Value()
+
+	for _, icon := range c.WeatherIcons {
+		if strings.Contains(strings.ToLower(icon), "night") == night {
+			return icon
+		}
+	}
+
+	return c.WeatherIcons[0]
 }
@@ -0,0 +1,44 @@
+package weatherstack
+
+import (
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestPrimaryIcon(t *testing.T) {
+	const (
+		dayIcon   = "https://assets.weatherstack.com/images/wsymbols01_png_64/wsymbol_0001_sunny.png"
+		nightIcon = "https://assets.weatherstack.com/images/wsymbols01_png_64/wsymbol_0008_clear_sky_night.png"
+	)
+
+	tests := []struct {
+		name  string
+		isDay bool
+		icons []string
+		want  string
+	}{
+		{"no icons", true, nil, ""},
+		{"single day icon by day", true, []string{dayIcon}, dayIcon},
+		{"single day icon by night", false, []string{dayIcon}, dayIcon},
+		{"single night icon by day", true, []string{nightIcon}, nightIcon},
+		{"day and night icons by day", true, []string{dayIcon, nightIcon}, dayIcon},
+		{"day and night icons by night", false, []string{dayIcon, nightIcon}, nightIcon},
+		{"night and day icons by day", true, []string{nightIcon, dayIcon}, dayIcon},
+		{"night and day icons by night", false, []string{nightIcon, dayIcon}, nightIcon},
+		{"only day icons by night", false, []string{dayIcon, dayIcon + "?v=2"}, dayIcon},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := CurrentWeather{
+				WeatherIcons: test.icons,
+				IsDay:        w_types.YesNoString(test.isDay),
+			}
+
+			if got := current.PrimaryIcon(); got != test.want {
+				t.Errorf("PrimaryIcon() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
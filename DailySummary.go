@@ -0,0 +1,74 @@
+package weatherstack
+
+// DailySummary holds daily statistics computed from the hourly records, in the units the data was requested in
+type DailySummary struct {
+	HourlyCount         int
+	MinTemperature      float64
+	MaxTemperature      float64
+	MeanTemperature     float64
+	TotalPrecip         float64 // see PrecipFromHourly
+	MeanHumidity        float64
+	MaxWindGust         float64
+	DominantWeatherCode WeatherCode // most frequent code, ties resolved by the highest Severity, then the lowest code
+}
+
+// Summary computes the daily statistics from the hourly records, ok is false when there are no hourly records.
+// Means are unweighted averages over the records.
+func (w Weather) Summary() (summary DailySummary, ok bool) {
+	if len(w.Hourly) == 0 {
+		return DailySummary{}, false
+	}
+
+	summary.HourlyCount = len(w.Hourly)
+	summary.MinTemperature = w.Hourly[0].Temperature.Value()
+	summary.MaxTemperature = w.Hourly[0].Temperature.Value()
+	summary.TotalPrecip, _ = w.PrecipFromHourly()
+
+	var temperatureSum, humiditySum float64
+	codeCounts := make(map[WeatherCode]int)
+
+	for _, hourly := range w.Hourly {
+		temperature := hourly.Temperature.Value()
+		if temperature < summary.MinTemperature {
+			summary.MinTemperature = temperature
+		}
+		if temperature > summary.MaxTemperature {
+			summary.MaxTemperature = temperature
+		}
+		temperatureSum += temperature
+
+		humiditySum += float64(hourly.Humidity.Value())
+
+		if windGust := hourly.Windgust.Value(); hourly.Windgust.Valid && windGust > summary.MaxWindGust {
+			summary.MaxWindGust = windGust
+		}
+
+		codeCounts[hourly.WeatherCode]++
+	}
+
+	summary.MeanTemperature = temperatureSum / float64(len(w.Hourly))
+	summary.MeanHumidity = humiditySum / float64(len(w.Hourly))
+	summary.DominantWeatherCode = dominantWeatherCode(codeCounts)
+
+	return summary, true
+}
+
+func dominantWeatherCode(codeCounts map[WeatherCode]int) WeatherCode {
+	var dominant WeatherCode
+	dominantCount := 0
+
+	for code, count := range codeCounts {
+		switch {
+		case count > dominantCount:
+		case count < dominantCount:
+			continue
+		case code.Severity() > dominant.Severity():
+		case code.Severity() < dominant.Severity() || code > dominant:
+			continue
+		}
+
+		dominant, dominantCount = code, count
+	}
+
+	return dominant
+}
@@ -0,0 +1,94 @@
+package weatherstack
+
+import "math"
+
+// HeatingDegreeDays returns how far the day's mean of MinTemp and MaxTemp is below baseCelsius (commonly 18), zero if it is not.
+// Degree days are in °C regardless of the units the data was requested in.
+func (w Weather) HeatingDegreeDays(baseCelsius float64) float64 {
+	return math.Max(0, baseCelsius-w.meanCelsius())
+}
+
+// CoolingDegreeDays returns how far the day's mean of MinTemp and MaxTemp is above baseCelsius, zero if it is not
+func (w Weather) CoolingDegreeDays(baseCelsius float64) float64 {
+	return math.Max(0, w.meanCelsius()-baseCelsius)
+}
+
+type GrowingDegreeDaysConfig struct {
+	BaseCelsius  float64  // below which there is no growth, e.g. 10 for maize
+	UpperCelsius *float64 // above which growth does not increase, e.g. 30 for maize, none if nil
+}
+
+// GrowingDegreeDays returns the growing degree days of the day, MinTemp and MaxTemp being clamped
+// to the range of config before averaging
+func (w Weather) GrowingDegreeDays(config GrowingDegreeDaysConfig) float64 {
+	clamp := func(celsius float64) float64 {
+		if config.UpperCelsius != nil && celsius > *config.UpperCelsius {
+			celsius = *config.UpperCelsius
+		}
+		return math.Max(celsius, config.BaseCelsius)
+	}
+
+	min := clamp(temperatureToCelsius(w.MinTemp.Value(), w.units))
+	max := clamp(temperatureToCelsius(w.MaxTemp.Value(), w.units))
+
+	return (min+max)/2 - config.BaseCelsius
+}
+
+// FrostHours returns the number of hours with a temperature at or below 0 °C, each hourly record
+// counting for the interval it represents, see PrecipFromHourly. ok is false when there are no hourly records.
+func (w Weather) FrostHours() (hours float64, ok bool) {
+	if len(w.Hourly) == 0 {
+		return 0, false
+	}
+
+	intervalHours := w.intervalHours()
+
+	for _, hourly := range w.Hourly {
+		if temperatureToCelsius(hourly.Temperature.Value(), hourly.units) <= 0 {
+			hours += intervalHours
+		}
+	}
+
+	return hours, true
+}
+
+func (w Weather) meanCelsius() float64 {
+	return (temperatureToCelsius(w.MinTemp.Value(), w.units) + temperatureToCelsius(w.MaxTemp.Value(), w.units)) / 2
+}
+
+// HeatingDegreeDays returns the sum of the heating degree days of all days
+func (r *HistoricalResponse) HeatingDegreeDays(baseCelsius float64) (degreeDays float64) {
+	for _, day := range r.Historical {
+		degreeDays += day.HeatingDegreeDays(baseCelsius)
+	}
+
+	return degreeDays
+}
+
+// CoolingDegreeDays returns the sum of the cooling degree days of all days
+func (r *HistoricalResponse) CoolingDegreeDays(baseCelsius float64) (degreeDays float64) {
+	for _, day := range r.Historical {
+		degreeDays += day.CoolingDegreeDays(baseCelsius)
+	}
+
+	return degreeDays
+}
+
+// GrowingDegreeDays returns the sum of the growing degree days of all days
+func (r *HistoricalResponse) GrowingDegreeDays(config GrowingDegreeDaysConfig) (degreeDays float64) {
+	for _, day := range r.Historical {
+		degreeDays += day.GrowingDegreeDays(config)
+	}
+
+	return degreeDays
+}
+
+// FrostHours returns the sum of the frost hours of all days, days without hourly records are skipped
+func (r *HistoricalResponse) FrostHours() (hours float64) {
+	for _, day := range r.Historical {
+		dayHours, _ := day.FrostHours()
+		hours += dayHours
+	}
+
+	return hours
+}
@@ -0,0 +1,189 @@
+package weatherstack
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+const diskCacheExtension string = ".cache"
+
+type DiskCacheConfig struct {
+	Directory string // created if it does not exist
+	MaxBytes  int64  // total size of the cached responses, the least recently written ones being evicted first, unlimited if 0
+}
+
+// DiskCache is a Cache persisting responses as files in a directory, so they survive process restarts.
+// Each entry is a file named by the hash of its key, which holds the endpoint, query, dates, units and other parameters.
+// Expired entries are removed when read.
+type DiskCache struct {
+	config DiskCacheConfig
+	mutex  sync.Mutex
+}
+
+func NewDiskCache(config DiskCacheConfig) (*DiskCache, *errortools.Error) {
+	if config.Directory == "" {
+		return nil, errortools.ErrorMessage("Directory not provided")
+	}
+
+	if config.MaxBytes < 0 {
+		return nil, errortools.ErrorMessage("MaxBytes must not be negative")
+	}
+
+	err := os.MkdirAll(config.Directory, 0755)
+	if err != nil {
+		return nil, errortools.ErrorMessage(err)
+	}
+
+	return &DiskCache{config: config}, nil
+}
+
+// Get returns the entry of key, read errors being treated as a miss
+func (cache *DiskCache) Get(key string) ([]byte, bool) {
+	fileName := cache.fileName(key)
+
+	value, expired, ok := readDiskCacheEntry(fileName)
+	if !ok {
+		return nil, false
+	}
+
+	if expired {
+		cache.removeExpired(fileName)
+		return nil, false
+	}
+
+	return value, true
+}
+
+// removeExpired removes the entry in fileName unless Set replaced it by an entry that has not expired since it was read
+func (cache *DiskCache) removeExpired(fileName string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if _, expired, ok := readDiskCacheEntry(fileName); ok && expired {
+		os.Remove(fileName)
+	}
+}
+
+// readDiskCacheEntry reads an entry, being the expiry (unix seconds, 0 for never) on the first line followed by the value
+func readDiskCacheEntry(fileName string) (value []byte, expired bool, ok bool) {
+	b, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, false, false
+	}
+
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return nil, false, false
+	}
+
+	expires, err := strconv.ParseInt(string(b[:i]), 10, 64)
+	if err != nil {
+		return nil, false, false
+	}
+
+	return b[i+1:], expires != 0 && time.Now().Unix() >= expires, true
+}
+
+// Set writes the entry of key, write errors being ignored since the response is returned regardless
+func (cache *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).Unix()
+	}
+
+	b := append([]byte(strconv.FormatInt(expires, 10)+"\n"), value...)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// write to a temporary file first, so that a crash never leaves a partial entry
+	file, err := ioutil.TempFile(cache.config.Directory, "tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(b)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), cache.fileName(key))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return
+	}
+
+	cache.evict()
+}
+
+// Clear removes all entries
+func (cache *DiskCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for _, fileInfo := range cache.entries() {
+		os.Remove(filepath.Join(cache.config.Directory, fileInfo.Name()))
+	}
+}
+
+// evict removes the least recently written entries as long as MaxBytes is exceeded
+func (cache *DiskCache) evict() {
+	if cache.config.MaxBytes == 0 {
+		return
+	}
+
+	entries := cache.entries()
+
+	var size int64
+	for _, fileInfo := range entries {
+		size += fileInfo.Size()
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	for _, fileInfo := range entries {
+		if size <= cache.config.MaxBytes {
+			break
+		}
+
+		if os.Remove(filepath.Join(cache.config.Directory, fileInfo.Name())) == nil {
+			size -= fileInfo.Size()
+		}
+	}
+}
+
+func (cache *DiskCache) entries() []os.FileInfo {
+	fileInfos, err := ioutil.ReadDir(cache.config.Directory)
+	if err != nil {
+		return nil
+	}
+
+	entries := []os.FileInfo{}
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() && strings.HasSuffix(fileInfo.Name(), diskCacheExtension) {
+			entries = append(entries, fileInfo)
+		}
+	}
+
+	return entries
+}
+
+func (cache *DiskCache) fileName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(cache.config.Directory, hex.EncodeToString(hash[:])+diskCacheExtension)
+}
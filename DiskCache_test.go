@@ -0,0 +1,30 @@
+package weatherstack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiskCacheExpiry(t *testing.T) {
+	cache, e := NewDiskCache(DiskCacheConfig{Directory: t.TempDir()})
+	if e != nil {
+		t.Fatal(e.Message())
+	}
+
+	cache.Set("key", []byte("expired"), time.Nanosecond)
+	if value, ok := cache.Get("key"); ok {
+		t.Errorf("Get() = %s, want a miss for an expired entry", value)
+	}
+	if len(cache.entries()) != 0 {
+		t.Errorf("got %v entries, want the expired entry removed", len(cache.entries()))
+	}
+
+	// an entry replaced after it was read as expired is kept
+	cache.Set("key", []byte("expired"), time.Nanosecond)
+	cache.Set("key", []byte("fresh"), time.Hour)
+	cache.removeExpired(cache.fileName("key"))
+
+	if value, ok := cache.Get("key"); !ok || string(value) != "fresh" {
+		t.Errorf("Get() = %s, %v, want fresh", value, ok)
+	}
+}
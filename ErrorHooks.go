@@ -0,0 +1,141 @@
+package weatherstack
+
+import (
+	"net/http"
+	"net/url"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// Logger receives log records as a message followed by alternating keys and values, e.g. a *slog.Logger.
+// For zap, wrap a SugaredLogger calling Debugw and Errorw.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// RequestError is the error returned by the endpoint methods of Service and passed to ServiceConfig.ErrorHandler.
+// It unwraps to the cause, e.g. ErrQuotaExhausted, ErrCircuitOpen, ErrCacheMiss, ErrUsageBudgetExceeded, context.Canceled,
+// a *WeatherstackError or a *RateLimitedError, so errors.Is and errors.As can be used.
+// Code expecting the *errortools.Error of the leapforce libraries can use ErrorTools.
+type RequestError struct {
+	URL        string // without access key, empty if the request was refused before building its URL
+	StatusCode int    // 0 if no response was received
+	Attempts   int    // 0 if the request was refused before sending it
+	Err        error  // the cause, nil if the error has no typed cause, e.g. an invalid config
+	e          *errortools.Error
+}
+
+func (err *RequestError) Error() string {
+	return err.Message()
+}
+
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
+// Message returns the message of the error, as *errortools.Error does
+func (err *RequestError) Message() string {
+	if err.e != nil {
+		return err.e.Message()
+	}
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+
+	return ""
+}
+
+func (err *RequestError) SetMessage(message interface{}) {
+	err.errorTools().SetMessage(message)
+}
+
+func (err *RequestError) SetMessagef(format string, a ...interface{}) {
+	err.errorTools().SetMessagef(format, a...)
+}
+
+func (err *RequestError) SetExtra(key string, value string) {
+	err.errorTools().SetExtra(key, value)
+}
+
+// ErrorTools returns the error as an *errortools.Error including its extras, nil if err is nil
+func (err *RequestError) ErrorTools() *errortools.Error {
+	if err == nil {
+		return nil
+	}
+
+	return err.errorTools()
+}
+
+func (err *RequestError) errorTools() *errortools.Error {
+	if err.e == nil {
+		err.e = errortools.ErrorMessage(err.Message())
+	}
+
+	return err.e
+}
+
+// Err returns e as an error, being nil if e is nil. Returning a nil *RequestError as an error would give a non-nil error.
+func Err(e *RequestError) error {
+	if e == nil {
+		return nil
+	}
+
+	return e
+}
+
+// newRequestError returns a *RequestError with the message and extras of e and cause, nil if e is nil
+func newRequestError(e *errortools.Error, cause error) *RequestError {
+	if e == nil {
+		return nil
+	}
+
+	return &RequestError{Err: cause, e: e}
+}
+
+// requestErrorOf returns a *RequestError with the message and cause err
+func requestErrorOf(err error) *RequestError {
+	return newRequestError(errortools.ErrorMessage(err), err)
+}
+
+// reportError completes e with the request and passes it to the ErrorHandler and Logger, returning e
+func (service *Service) reportError(_url *url.URL, e *RequestError, response *http.Response, attempts int) *RequestError {
+	e.Attempts = attempts
+	if _url != nil {
+		e.URL = _url.String()
+	}
+	if response != nil {
+		e.StatusCode = response.StatusCode
+	}
+
+	if service.logger != nil {
+		service.logger.Error("weatherstack request failed", "url", e.URL, "status", e.StatusCode,
+			"attempts", e.Attempts, "error", e.Message())
+	}
+
+	if service.errorHandler != nil {
+		service.errorHandler(e)
+	}
+
+	return e
+}
+
+// logAttempt logs an attempt of a request at debug level, including the retry delay if the request is retried
+func (service *Service) logAttempt(info RequestInfo) {
+	if service.logger == nil {
+		return
+	}
+
+	args := []interface{}{"url", info.URL, "attempt", info.Attempt, "status", info.StatusCode, "duration", info.Duration}
+	if info.Error != nil {
+		args = append(args, "error", info.Error.Message())
+	}
+	if info.WeatherstackError != nil {
+		args = append(args, "code", int(info.WeatherstackError.Code), "type", info.WeatherstackError.Type)
+	}
+	if info.RetryDelay > 0 {
+		args = append(args, "retry_delay", info.RetryDelay)
+	}
+
+	service.logger.Debug("weatherstack request", args...)
+}
@@ -0,0 +1,187 @@
+package weatherstack
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+func newTestService(t *testing.T, handler http.HandlerFunc, config ServiceConfig) *Service {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.AccessKey = "key"
+	config.BaseURL = server.URL
+	if config.Retry == nil {
+		config.Retry = &RetryConfig{MaxAttempts: 1}
+	}
+
+	service, e := NewService(&config)
+	if e != nil {
+		t.Fatal(e.Message())
+	}
+
+	return service
+}
+
+func TestErrUnwrapsWeatherstackError(t *testing.T) {
+	var handled error
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"error":{"code":104,"type":"usage_limit_reached","info":"Your monthly usage limit has been reached."}}`))
+	}, ServiceConfig{ErrorHandler: func(err error) { handled = err }})
+
+	_, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+	if e == nil {
+		t.Fatal("GetCurrentWeather() returned no error")
+	}
+
+	for name, err := range map[string]error{"Err": Err(e), "ErrorHandler": handled} {
+		var apiError *WeatherstackError
+		if !errors.As(err, &apiError) {
+			t.Fatalf("%s: errors.As(*WeatherstackError) failed for %v", name, err)
+		}
+		if apiError.Code != ErrorCodeUsageLimitReached || apiError.Type != "usage_limit_reached" {
+			t.Errorf("%s: got %+v", name, apiError)
+		}
+	}
+}
+
+func TestWeatherstackErrorOf(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"error":{"code":615,"type":"request_failed","info":"Your API request failed."}}`))
+	}, ServiceConfig{})
+
+	_, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+
+	apiError, ok := WeatherstackErrorOf(e)
+	if !ok {
+		t.Fatalf("WeatherstackErrorOf() not ok for %v", Err(e))
+	}
+	if apiError.Code != 615 || apiError.Type != "request_failed" || apiError.Info != "Your API request failed." {
+		t.Errorf("WeatherstackErrorOf() = %+v", apiError)
+	}
+
+	if _, ok := WeatherstackErrorOf(newRequestError(errortools.ErrorMessage("Invalid Query"), nil)); ok {
+		t.Error("WeatherstackErrorOf() ok for a local error")
+	}
+	if _, ok := WeatherstackErrorOf(nil); ok {
+		t.Error("WeatherstackErrorOf(nil) ok")
+	}
+}
+
+func TestErrUnwrapsRateLimitedError(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, ServiceConfig{})
+
+	_, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+
+	var rateLimitedError *RateLimitedError
+	if !errors.As(Err(e), &rateLimitedError) {
+		t.Fatalf("errors.As(*RateLimitedError) failed for %v", Err(e))
+	}
+	if rateLimitedError.RetryAfter.Seconds() != 2 {
+		t.Errorf("RetryAfter = %s, want 2s", rateLimitedError.RetryAfter)
+	}
+}
+
+func TestErrUnwrapsSentinelAfterRewording(t *testing.T) {
+	e := requestErrorOf(ErrQuotaExhausted)
+	e.SetMessagef("Requesting Amsterdam failed: %s", e.Message())
+
+	if !errors.Is(Err(e), ErrQuotaExhausted) {
+		t.Errorf("errors.Is(ErrQuotaExhausted) failed for %v", Err(e))
+	}
+	if e.Message() != "Requesting Amsterdam failed: "+ErrQuotaExhausted.Error() || e.ErrorTools().Message() != e.Message() {
+		t.Errorf("Message() = %q, ErrorTools().Message() = %q", e.Message(), e.ErrorTools().Message())
+	}
+}
+
+func TestErrorHandlerGetsReturnedError(t *testing.T) {
+	var handled error
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, ServiceConfig{ErrorHandler: func(err error) { handled = err }})
+
+	_, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+	if e == nil {
+		t.Fatal("GetCurrentWeather() returned no error")
+	}
+
+	if handled != Err(e) {
+		t.Errorf("ErrorHandler got %v, want the returned error %v", handled, e)
+	}
+	if e.StatusCode != http.StatusServiceUnavailable || e.Attempts != 1 || !strings.Contains(e.URL, "/current?") ||
+		strings.Contains(e.URL, "access_key") {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestErrWithoutCause(t *testing.T) {
+	if Err(nil) != nil {
+		t.Error("Err(nil) is not nil")
+	}
+
+	err := Err(newRequestError(errortools.ErrorMessage("Invalid Query"), nil))
+	if err == nil || err.Error() != "Invalid Query" || errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+type recordingLogger struct {
+	debug [][]interface{}
+}
+
+func (logger *recordingLogger) Debug(msg string, args ...interface{}) {
+	logger.debug = append(logger.debug, args)
+}
+
+func (logger *recordingLogger) Error(msg string, args ...interface{}) {}
+
+func TestLogAttemptIncludesWeatherstackError(t *testing.T) {
+	logger := &recordingLogger{}
+	requests := 0
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":false,"error":{"code":615,"type":"request_failed","info":"Your API request failed."}}`))
+	}, ServiceConfig{Logger: logger, Retry: &RetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond}})
+
+	service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+
+	if len(logger.debug) != 2 {
+		t.Fatalf("got %v debug records, want 2", len(logger.debug))
+	}
+
+	for i, args := range logger.debug {
+		fields := map[string]interface{}{}
+		for j := 0; j+1 < len(args); j += 2 {
+			fields[args[j].(string)] = args[j+1]
+		}
+
+		if fields["attempt"] != i+1 {
+			t.Errorf("attempt %v logged attempt %v", i+1, fields["attempt"])
+		}
+		if i == 0 && fields["status"] != http.StatusServiceUnavailable {
+			t.Errorf("attempt 1 logged status %v", fields["status"])
+		}
+		if i == 1 && (fields["code"] != 615 || fields["type"] != "request_failed") {
+			t.Errorf("attempt 2 logged code %v and type %v", fields["code"], fields["type"])
+		}
+		if strings.Contains(fields["url"].(string), "key") {
+			t.Errorf("attempt %v logged the access key: %s", i+1, fields["url"])
+		}
+		if _, retried := fields["retry_delay"]; retried != (i == 0) {
+			t.Errorf("attempt %v logged retry_delay %v", i+1, fields["retry_delay"])
+		}
+	}
+}
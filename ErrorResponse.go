@@ -1,12 +1,135 @@
 package weatherstack
 
-// ErrorResponse stores general Ridder API error response
-//
+import (
+	"errors"
+	"fmt"
+
+	go_http "github.com/leapforce-libraries/go_http"
+)
+
+// ErrorResponse stores general Weatherstack API error response
+// Weatherstack returns it with HTTP status 200, "success" being false
 type ErrorResponse struct {
-	Success string `json:"success"`
-	Error   struct {
-		Code int    `json:"code"`
-		Type string `json:"type"`
-		Info string `json:"info"`
-	} `json:"error"`
+	Success *bool             `json:"success"`
+	Error   WeatherstackError `json:"error"`
+}
+
+// WeatherstackError is the error object returned by the Weatherstack API
+type WeatherstackError struct {
+	Code ErrorCode `json:"code"`
+	Type string    `json:"type"`
+	Info string    `json:"info"`
+}
+
+func (err *WeatherstackError) Error() string {
+	return fmt.Sprintf("%s (%v: %s)", err.Info, err.Code, err.Type)
+}
+
+// extra fields set on errors returned by the service
+const (
+	ErrorExtraHTTPStatusCode   string = "http_status_code"
+	ErrorExtraURL              string = "url" // with the access key redacted
+	ErrorExtraWeatherstackCode string = "weatherstack_code"
+	ErrorExtraWeatherstackType string = "weatherstack_type"
+	ErrorExtraRetryAfter       string = "retry_after" // seconds, see RateLimitedError
+)
+
+// ErrorCode is the code of an error returned by Weatherstack
+type ErrorCode int
+
+// error codes returned by Weatherstack in WeatherstackError.Code
+const (
+	ErrorCodeNotFound                   ErrorCode = 404
+	ErrorCodeInvalidAccessKey           ErrorCode = 101 // also returned for a missing access key
+	ErrorCodeInactiveUser               ErrorCode = 102
+	ErrorCodeInvalidAPIFunction         ErrorCode = 103
+	ErrorCodeUsageLimitReached          ErrorCode = 104
+	ErrorCodeFunctionAccessRestricted   ErrorCode = 105 // also returned for HTTPS on a plan not supporting it
+	ErrorCodeMissingQuery               ErrorCode = 601
+	ErrorCodeNoResults                  ErrorCode = 602
+	ErrorCodeHistoricalNotSupported     ErrorCode = 603
+	ErrorCodeBulkQueriesNotSupported    ErrorCode = 604
+	ErrorCodeInvalidLanguage            ErrorCode = 605
+	ErrorCodeInvalidUnit                ErrorCode = 606
+	ErrorCodeInvalidInterval            ErrorCode = 607
+	ErrorCodeInvalidForecastDays        ErrorCode = 608
+	ErrorCodeForecastDaysNotSupported   ErrorCode = 609
+	ErrorCodeInvalidHistoricalDate      ErrorCode = 611
+	ErrorCodeInvalidHistoricalTimeFrame ErrorCode = 612
+	ErrorCodeHistoricalTimeFrameTooLong ErrorCode = 613
+	ErrorCodeMissingHistoricalDate      ErrorCode = 614
+	ErrorCodeRequestFailed              ErrorCode = 615 // the request, e.g. the query, could not be processed
+)
+
+// IsAuthError reports whether the access key is missing, invalid or belongs to an inactive account
+func (code ErrorCode) IsAuthError() bool {
+	return code == ErrorCodeInvalidAccessKey || code == ErrorCodeInactiveUser
+}
+
+// IsQuotaError reports whether the monthly usage limit of the plan is reached
+func (code ErrorCode) IsQuotaError() bool {
+	return code == ErrorCodeUsageLimitReached
+}
+
+// IsPlanError reports whether the requested function is not supported by the plan
+func (code ErrorCode) IsPlanError() bool {
+	switch code {
+	case ErrorCodeFunctionAccessRestricted,
+		ErrorCodeHistoricalNotSupported,
+		ErrorCodeBulkQueriesNotSupported,
+		ErrorCodeForecastDaysNotSupported:
+		return true
+	}
+
+	return false
+}
+
+// IsInvalidRequest reports whether the request itself is invalid, e.g. a missing or unknown query, an invalid parameter
+// or historical date
+func (code ErrorCode) IsInvalidRequest() bool {
+	switch code {
+	case ErrorCodeNotFound,
+		ErrorCodeInvalidAPIFunction,
+		ErrorCodeMissingQuery,
+		ErrorCodeNoResults,
+		ErrorCodeInvalidLanguage,
+		ErrorCodeInvalidUnit,
+		ErrorCodeInvalidInterval,
+		ErrorCodeInvalidForecastDays,
+		ErrorCodeInvalidHistoricalDate,
+		ErrorCodeInvalidHistoricalTimeFrame,
+		ErrorCodeHistoricalTimeFrameTooLong,
+		ErrorCodeMissingHistoricalDate,
+		ErrorCodeRequestFailed:
+		return true
+	}
+
+	return false
+}
+
+// IsRetryable reports whether sending the same request again may succeed. Weatherstack errors are deterministic,
+// so this is false for all codes, network errors, 5xx and 429 responses are retried instead (see RetryConfig).
+func (code ErrorCode) IsRetryable() bool {
+	return false
+}
+
+// error type returned with ErrorCodeFunctionAccessRestricted when HTTPS is used on a plan not supporting it
+const ErrorTypeHTTPSAccessRestricted string = "https_access_restricted"
+
+// WeatherstackErrorOf returns the Weatherstack error object e was caused by, ok is false if e is not an error of the Weatherstack API.
+// Equivalent to errors.As(Err(e), &weatherstackError).
+func WeatherstackErrorOf(e *RequestError) (weatherstackError *WeatherstackError, ok bool) {
+	ok = errors.As(Err(e), &weatherstackError)
+
+	return weatherstackError, ok
+}
+
+// weatherstackError returns the Weatherstack error object decoded for requestConfig, if any
+func weatherstackError(requestConfig *go_http.RequestConfig) *WeatherstackError {
+	errorResponse, ok := requestConfig.ErrorModel.(*ErrorResponse)
+	if !ok || errorResponse.Error.Code == 0 {
+		return nil
+	}
+
+	return &errorResponse.Error
 }
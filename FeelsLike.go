@@ -0,0 +1,68 @@
+package weatherstack
+
+import "math"
+
+type FeelsLikeCause string
+
+const (
+	FeelsLikeCauseNone      FeelsLikeCause = "none"
+	FeelsLikeCauseWindChill FeelsLikeCause = "wind_chill"
+	FeelsLikeCauseHeatIndex FeelsLikeCause = "heat_index"
+)
+
+// WindChill computes the wind chill temperature (°C) from temperature (°C) and wind speed (km/h)
+// using the North American / UK wind chill index.
+// ok is false outside the formula's domain (temperature above 10°C or wind speed below 4.8 km/h).
+func WindChill(tempC, windKmH float64) (windChill float64, ok bool) {
+	if tempC > 10 || windKmH < 4.8 {
+		return tempC, false
+	}
+
+	v := math.Pow(windKmH, 0.16)
+
+	return 13.12 + 0.6215*tempC - 11.37*v + 0.3965*tempC*v, true
+}
+
+// HeatIndex computes the heat index (°C) from temperature (°C) and relative humidity (%)
+// using the Rothfusz regression of the US National Weather Service.
+// ok is false outside the formula's domain (temperature below 26.7°C or humidity below 40%).
+func HeatIndex(tempC, humidity float64) (heatIndex float64, ok bool) {
+	if tempC < 26.7 || humidity < 40 {
+		return tempC, false
+	}
+
+	t := temperatureFromCelsius(tempC, UnitsFahrenheit)
+	rh := humidity
+
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh -
+		0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+		0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+	return temperatureToCelsius(hi, UnitsFahrenheit), true
+}
+
+// FeelsLikeDriver explains why FeelsLike differs from Temperature by returning the adjustment
+// (wind chill or heat index) that deviates most from the actual temperature, in the units the data was requested in.
+// FeelsLikeCauseNone is returned when FeelsLike equals Temperature or neither adjustment applies.
+func (c CurrentWeather) FeelsLikeDriver() FeelsLikeCause {
+	if c.FeelsLike == c.Temperature {
+		return FeelsLikeCauseNone
+	}
+
+	tempC := c.TemperatureValue().Celsius()
+
+	windChill, windChillOK := WindChill(tempC, c.WindSpeedValue().KmH())
+	heatIndex, heatIndexOK := HeatIndex(tempC, float64(c.Humidity))
+
+	windChillEffect := tempC - windChill
+	heatIndexEffect := heatIndex - tempC
+
+	switch {
+	case windChillOK && c.FeelsLike < c.Temperature && windChillEffect >= heatIndexEffect:
+		return FeelsLikeCauseWindChill
+	case heatIndexOK && c.FeelsLike > c.Temperature:
+		return FeelsLikeCauseHeatIndex
+	}
+
+	return FeelsLikeCauseNone
+}
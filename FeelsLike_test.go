@@ -0,0 +1,30 @@
+package weatherstack
+
+import (
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestFeelsLikeDriverUsesRequestUnits(t *testing.T) {
+	tests := []struct {
+		name    string
+		current CurrentWeather
+		want    FeelsLikeCause
+	}{
+		{"metric wind chill", CurrentWeather{Temperature: -5, FeelsLike: -12, WindSpeed: 30, Humidity: 80, units: UnitsMetric}, FeelsLikeCauseWindChill},
+		{"scientific wind chill", CurrentWeather{Temperature: 268.15, FeelsLike: 261.15, WindSpeed: 8.3, Humidity: 80, units: UnitsScientific}, FeelsLikeCauseWindChill},
+		{"fahrenheit heat index", CurrentWeather{Temperature: 95, FeelsLike: 110, WindSpeed: 3, Humidity: 70, units: UnitsFahrenheit}, FeelsLikeCauseHeatIndex},
+		{"equal", CurrentWeather{Temperature: 15, FeelsLike: 15, units: UnitsMetric}, FeelsLikeCauseNone},
+		// 268.15 read as °C would be far outside the wind chill domain
+		{"scientific is not read as metric", CurrentWeather{Temperature: w_types.Float64OrString(268.15), FeelsLike: 261.15, WindSpeed: 8.3, units: UnitsMetric}, FeelsLikeCauseNone},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.current.FeelsLikeDriver(); got != test.want {
+				t.Errorf("FeelsLikeDriver() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package weatherstack
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrFixtureNotFound is the error of requests without fixture in FixtureModeReplay, they are not retried
+var ErrFixtureNotFound = errors.New("no fixture for request")
+
+type FixtureMode string
+
+const (
+	FixtureModeRecord FixtureMode = "record" // send requests to the API and store the responses
+	FixtureModeReplay FixtureMode = "replay" // return stored responses, failing for requests without fixture
+)
+
+// FixturesConfig configures recording API responses to, or replaying them from, JSON files in Directory.
+// Fixtures are matched on endpoint and query parameters, the access key excluded.
+type FixturesConfig struct {
+	Directory string
+	Mode      FixtureMode
+}
+
+type fixture struct {
+	URL        string          `json:"url"` // without access key
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// fixtureTransport records or replays the responses of the requests it sends
+type fixtureTransport struct {
+	config FixturesConfig
+	base   http.RoundTripper
+}
+
+func (transport *fixtureTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	_url := *request.URL
+	query := _url.Query()
+	query.Del("access_key")
+	_url.RawQuery = query.Encode()
+
+	fileName := filepath.Join(transport.config.Directory, fixtureFileName(_url.Path, _url.RawQuery))
+
+	if transport.config.Mode == FixtureModeReplay {
+		b, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %s", ErrFixtureNotFound, _url.String(), err.Error())
+		}
+
+		f := fixture{}
+		err = json.Unmarshal(b, &f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %s", fileName, err.Error())
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%v %s", f.StatusCode, http.StatusText(f.StatusCode)),
+			StatusCode:    f.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        f.Header,
+			Body:          ioutil.NopCloser(bytes.NewReader(f.Body)),
+			ContentLength: int64(len(f.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	response, err := transport.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// only JSON bodies can be stored as is
+	if json.Valid(body) {
+		b, err := json.MarshalIndent(fixture{
+			URL:        _url.String(),
+			StatusCode: response.StatusCode,
+			Header:     response.Header,
+			Body:       body,
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.MkdirAll(transport.config.Directory, 0755)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ioutil.WriteFile(fileName, b, 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// fixtureFileName returns a file name made of the endpoint and a hash of the (sorted, encoded) query parameters
+func fixtureFileName(urlPath string, rawQuery string) string {
+	hash := sha1.Sum([]byte(rawQuery))
+	endpoint := strings.Trim(path.Base(urlPath), "/.")
+	if endpoint == "" {
+		endpoint = "root"
+	}
+
+	return fmt.Sprintf("%s_%s.json", endpoint, hex.EncodeToString(hash[:8]))
+}
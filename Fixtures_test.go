@@ -0,0 +1,33 @@
+package weatherstack
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReplayMissIsNotRetried(t *testing.T) {
+	requests := 0
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}, ServiceConfig{
+		Fixtures:       &FixturesConfig{Directory: t.TempDir(), Mode: FixtureModeReplay},
+		Retry:          &RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, e := service.GetCurrentWeather(GetCurrentWeatherConfig{Query: "Amsterdam"})
+		if !errors.Is(Err(e), ErrFixtureNotFound) {
+			t.Fatalf("GetCurrentWeather() error = %v, want ErrFixtureNotFound", Err(e))
+		}
+		if e.Attempts != 1 {
+			t.Errorf("Attempts = %v, want 1", e.Attempts)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("got %v requests, want 0", requests)
+	}
+}
@@ -0,0 +1,40 @@
+package weatherstack
+
+import "math"
+
+// fog rule thresholds
+const (
+	fogMaxVisibilityKm    float64 = 1
+	fogMinHumidity        int64   = 90
+	fogMaxDewpointSpreadC float64 = 2.5
+)
+
+// DewpointSpread returns the difference between Temperature and Dewpoint in °C, NaN if Dewpoint is absent
+func (h HourlyWeather) DewpointSpread() float64 {
+	if !h.Dewpoint.Valid {
+		return math.NaN()
+	}
+
+	return h.TemperatureValue().Celsius() - h.DewpointValue().Celsius()
+}
+
+// FogLikely reports whether fog is likely: visibility below 1 km, humidity of at least 90%
+// and a dew-point spread of at most 2.5°C
+func (h HourlyWeather) FogLikely() bool {
+	return h.VisibilityValue().Kilometers() < fogMaxVisibilityKm &&
+		h.Humidity.Value() >= fogMinHumidity &&
+		h.DewpointSpread() <= fogMaxDewpointSpreadC
+}
+
+// FoggyHours returns the hourly records for which fog is likely
+func (w Weather) FoggyHours() []HourlyWeather {
+	foggyHours := []HourlyWeather{}
+
+	for _, hourly := range w.Hourly {
+		if hourly.FogLikely() {
+			foggyHours = append(foggyHours, hourly)
+		}
+	}
+
+	return foggyHours
+}
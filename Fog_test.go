@@ -0,0 +1,40 @@
+package weatherstack
+
+import (
+	"math"
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestFogLikelyUsesRequestUnits(t *testing.T) {
+	dewpoint := func(v float64) w_types.NullFloat64OrString {
+		return w_types.NullFloat64OrString{Float64: v, Valid: true}
+	}
+
+	tests := []struct {
+		name   string
+		hourly HourlyWeather
+		want   bool
+	}{
+		{"metric fog", HourlyWeather{Temperature: 5, Dewpoint: dewpoint(4), Visibility: 0.5, Humidity: 95, units: UnitsMetric}, true},
+		{"scientific fog", HourlyWeather{Temperature: 278.15, Dewpoint: dewpoint(277.15), Visibility: 0.5, Humidity: 95, units: UnitsScientific}, true},
+		// 4°F of spread is about 2.2°C, 0.5 miles about 0.8 km
+		{"fahrenheit fog", HourlyWeather{Temperature: 41, Dewpoint: dewpoint(37), Visibility: 0.5, Humidity: 95, units: UnitsFahrenheit}, true},
+		{"fahrenheit spread too large", HourlyWeather{Temperature: 41, Dewpoint: dewpoint(35), Visibility: 0.5, Humidity: 95, units: UnitsFahrenheit}, false},
+		{"visibility too high", HourlyWeather{Temperature: 5, Dewpoint: dewpoint(4), Visibility: 2, Humidity: 95, units: UnitsMetric}, false},
+		{"dewpoint absent", HourlyWeather{Temperature: 278.15, Visibility: 0.5, Humidity: 95, units: UnitsScientific}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.hourly.FogLikely(); got != test.want {
+				t.Errorf("FogLikely() = %v, want %v (spread %v)", got, test.want, test.hourly.DewpointSpread())
+			}
+		})
+	}
+
+	if spread := (HourlyWeather{Temperature: 5, units: UnitsMetric}).DewpointSpread(); !math.IsNaN(spread) {
+		t.Errorf("DewpointSpread() without dewpoint = %v, want NaN", spread)
+	}
+}
@@ -1,65 +1,130 @@
 package weatherstack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	errortools "github.com/leapforce-libraries/go_errortools"
 	go_http "github.com/leapforce-libraries/go_http"
 )
 
 type ForecastResponse struct {
-	Request  Request            `json:"request"`
-	Location Location           `json:"location"`
-	Current  CurrentWeather     `json:"current"`
-	Forecast map[string]Weather `json:"forecast"`
+	Request     Request                    `json:"request"`
+	Location    Location                   `json:"location"`
+	Current     CurrentWeather             `json:"current"`
+	Forecast    map[string]ForecastWeather `json:"forecast"`
+	RawResponse []byte                     `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true
+	Meta        *ResponseMeta              `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true and the response was not cached
 }
 
+// ForecastWeather is the weather of a single day as returned by the forecast endpoint
+type ForecastWeather = Weather
+
+// ForecastDay is an alias of ForecastWeather
+type ForecastDay = ForecastWeather
+
+// GetForecastConfig is an alias of GetForecastWeatherConfig
+type GetForecastConfig = GetForecastWeatherConfig
+
 type GetForecastWeatherConfig struct {
-	Query        string
-	ForecastDays *uint
-	Hourly       *Hourly
-	Interval     *Interval
-	Units        *Units
-	Language     *string
+	Query           string
+	Coordinates     *Coordinates // alternative to Query
+	ForecastDays    *int
+	Hourly          *Hourly
+	Interval        *Interval
+	Units           *Units
+	Language        *Language
+	BaseURLOverride *string
+	ExtraParams     url.Values     // merged into the query string, overriding parameters with the same name
+	Timeout         *time.Duration // of the call including retries, in addition to the deadline of the context
 }
 
-func (service *Service) GetForecastWeather(config GetForecastWeatherConfig) (*ForecastResponse, *errortools.Error) {
-	values := url.Values{}
+// Validate checks the config locally, returning all violations at once
+func (config GetForecastWeatherConfig) Validate() *errortools.Error {
+	var v violations
 
-	values.Add("query", config.Query)
+	v.add(validateQueryOrCoordinates(config.Query, config.Coordinates))
+	v.add(validateTimeout(config.Timeout))
+	v.add(validateParameters(config.Units, config.Interval, config.Language))
+	v.add(validateHourlyInterval(config.Hourly, config.Interval))
 
-	if config.ForecastDays != nil {
-		values.Add("forecast_days", fmt.Sprintf("%v", *config.ForecastDays))
+	if config.ForecastDays != nil && (*config.ForecastDays < 1 || *config.ForecastDays > MaxForecastDays) {
+		v.addf("ForecastDays must be between 1 and %v", MaxForecastDays)
 	}
 
-	if config.Hourly != nil {
-		values.Add("hourly", fmt.Sprintf("%v", int64(*config.Hourly)))
-	}
+	return v.error()
+}
 
-	if config.Interval != nil {
-		values.Add("interval", fmt.Sprintf("%v", int64(*config.Interval)))
-	}
+func (service *Service) GetForecastWeather(config GetForecastWeatherConfig) (*ForecastResponse, *RequestError) {
+	return service.GetForecastWeatherWithContext(context.Background(), config)
+}
 
-	if config.Units != nil {
-		values.Add("units", fmt.Sprintf("%s", string(*config.Units)))
-	}
+// GetForecast is an alias of GetForecastWeather
+func (service *Service) GetForecast(config GetForecastConfig) (*ForecastResponse, *RequestError) {
+	return service.GetForecastWeatherWithContext(context.Background(), config)
+}
+
+// GetForecastWithContext is an alias of GetForecastWeatherWithContext
+func (service *Service) GetForecastWithContext(ctx context.Context, config GetForecastConfig) (*ForecastResponse, *RequestError) {
+	return service.GetForecastWeatherWithContext(ctx, config)
+}
 
-	if config.Language != nil {
-		values.Add("language", *config.Language)
+func (service *Service) GetForecastWeatherWithContext(ctx context.Context, config GetForecastWeatherConfig) (*ForecastResponse, *RequestError) {
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	_url, e := service.forecastURL(config)
+	if e != nil {
+		return nil, newRequestError(e, nil)
 	}
 
 	forecastResponse := ForecastResponse{}
 
 	requestConfig := go_http.RequestConfig{
-		URL:           service.url(fmt.Sprintf("forecast?%s", values.Encode())),
+		URL:           _url,
 		ResponseModel: &forecastResponse,
 	}
 
-	_, _, e := service.get(&requestConfig)
+	_, _, requestError := service.get(ctx, &requestConfig)
+	if requestError != nil {
+		return nil, requestError
+	}
+
+	e = forecastResponse.Validate()
 	if e != nil {
-		return nil, e
+		return nil, newRequestError(e, nil)
 	}
 
+	service.locations.remember(config.Query, forecastResponse.Location)
+
 	return &forecastResponse, nil
 }
+
+func (service *Service) forecastURL(config GetForecastWeatherConfig) (string, *errortools.Error) {
+	config.Query, config.Coordinates = service.locations.rewrite(config.Query, config.Coordinates)
+	config.Units = service.unitsOrDefault(config.Units)
+	config.Hourly = service.hourlyOrImplied(config.Hourly, config.Interval)
+	config.Language = service.languageOrDefault(config.Language)
+
+	e := config.Validate()
+	if e != nil {
+		return "", e
+	}
+
+	query, e := resolveQuery(config.Query, config.Coordinates)
+	if e != nil {
+		return "", e
+	}
+
+	values := baseValues(query, config.Units, config.Hourly, config.Interval, config.Language)
+
+	if config.ForecastDays != nil {
+		values.Add("forecast_days", fmt.Sprintf("%v", *config.ForecastDays))
+	}
+
+	mergeExtraParams(values, config.ExtraParams)
+
+	return service.urlWithOverride(config.BaseURLOverride, fmt.Sprintf("forecast?%s", values.Encode()))
+}
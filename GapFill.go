@@ -0,0 +1,159 @@
+package weatherstack
+
+import (
+	"math"
+	"sort"
+
+	go_types "github.com/leapforce-libraries/go_types"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+// PrecipGapFill is how FillGaps fills the precipitation of an inserted record
+type PrecipGapFill int
+
+const (
+	PrecipGapFillZero         PrecipGapFill = iota // no precipitation
+	PrecipGapFillCarryForward                      // the precipitation of the previous record, or the next one at the start of the day
+)
+
+type GapFillConfig struct {
+	Interval Interval      // interval requested, determining the times of a complete day
+	Precip   PrecipGapFill // defaults to PrecipGapFillZero
+}
+
+// FillGaps returns a copy of the day with a record inserted for each time of the interval's grid that has no record,
+// ordered by Time. Inserted records have Interpolated set and are interpolated between the nearest records before and after:
+// linearly for temperatures, wind speed, pressure, humidity, visibility and cloud cover, along the shortest arc for the wind degree,
+// while the weather code and descriptions are carried forward. At the start or end of the day the nearest record is copied.
+// The ChanceOf fields and UVIndex of inserted records are left absent, as are Null fields not present on both sides.
+// Days without records with a valid Time are returned unchanged.
+func (w Weather) FillGaps(config GapFillConfig) Weather {
+	count := config.Interval.ExpectedHourlyCount()
+
+	known := map[int]HourlyWeather{}
+	minutes := []int{}
+	for _, hourly := range w.Hourly {
+		timeOfDay, err := hourly.TimeOfDay()
+		if err != nil {
+			continue
+		}
+		minute := timeOfDay.Hour()*60 + timeOfDay.Minute()
+		if _, ok := known[minute]; !ok {
+			known[minute] = hourly
+			minutes = append(minutes, minute)
+		}
+	}
+	if count == 0 || len(minutes) == 0 {
+		return w
+	}
+	sort.Ints(minutes)
+
+	filled := append([]HourlyWeather{}, w.Hourly...)
+	for i := 0; i < count; i++ {
+		minute := i * int(config.Interval) * 60
+		if _, ok := known[minute]; ok {
+			continue
+		}
+
+		// nearest records before and after the gap
+		j := sort.SearchInts(minutes, minute)
+		switch {
+		case j == 0:
+			filled = append(filled, interpolateHourly(known[minutes[0]], known[minutes[0]], 0, minute, config.Precip))
+		case j == len(minutes):
+			last := known[minutes[j-1]]
+			filled = append(filled, interpolateHourly(last, last, 0, minute, config.Precip))
+		default:
+			fraction := float64(minute-minutes[j-1]) / float64(minutes[j]-minutes[j-1])
+			filled = append(filled, interpolateHourly(known[minutes[j-1]], known[minutes[j]], fraction, minute, config.Precip))
+		}
+	}
+
+	sort.SliceStable(filled, func(i, j int) bool {
+		return filled[i].Time < filled[j].Time
+	})
+
+	w.Hourly = filled
+
+	return w
+}
+
+// FillGaps returns a copy of the response with the gaps in the hourly records of each day filled, see Weather.FillGaps.
+// The response itself is not modified.
+func (r *HistoricalResponse) FillGaps(config GapFillConfig) *HistoricalResponse {
+	filled := *r
+	filled.Historical = fillGaps(r.Historical, config)
+
+	return &filled
+}
+
+// FillGaps returns a copy of the response with the gaps in the hourly records of each day filled, see Weather.FillGaps.
+// The response itself is not modified.
+func (r *ForecastResponse) FillGaps(config GapFillConfig) *ForecastResponse {
+	filled := *r
+	filled.Forecast = fillGaps(r.Forecast, config)
+
+	return &filled
+}
+
+func fillGaps(days map[string]Weather, config GapFillConfig) map[string]Weather {
+	if days == nil {
+		return nil
+	}
+
+	filled := make(map[string]Weather, len(days))
+	for date, day := range days {
+		filled[date] = day.FillGaps(config)
+	}
+
+	return filled
+}
+
+// interpolateHourly returns the record at minute of the day, fraction of the way from before to after
+func interpolateHourly(before HourlyWeather, after HourlyWeather, fraction float64, minute int, precip PrecipGapFill) HourlyWeather {
+	linear := func(a, b float64) float64 {
+		return a + (b-a)*fraction
+	}
+	linearInt := func(a, b int64) w_types.Int64OrString {
+		return w_types.Int64OrString(math.Round(linear(float64(a), float64(b))))
+	}
+	linearNull := func(a, b w_types.NullFloat64OrString) w_types.NullFloat64OrString {
+		if !a.Valid || !b.Valid {
+			return w_types.NullFloat64OrString{}
+		}
+		return w_types.NullFloat64OrString{Float64: linear(a.Float64, b.Float64), Valid: true}
+	}
+
+	// shortest arc from the degree before to the degree after, e.g. 350° to 10° passing 0°
+	degreeBefore := float64(before.WindDegree.Value())
+	arc := math.Mod(float64(after.WindDegree.Value())-degreeBefore+540, 360) - 180
+	windDegree := int64(math.Round(math.Mod(degreeBefore+arc*fraction+360, 360))) % 360
+
+	hourly := HourlyWeather{
+		Temperature:         w_types.Float64OrString(linear(before.Temperature.Value(), after.Temperature.Value())),
+		WindSpeed:           w_types.Float64OrString(linear(before.WindSpeed.Value(), after.WindSpeed.Value())),
+		WindDegree:          w_types.Int64OrString(windDegree),
+		WindDir:             WindDirectionFromDegree(int(windDegree)).String(),
+		WeatherCode:         before.WeatherCode,
+		WeatherIcons:        before.WeatherIcons,
+		WeatherDescriptions: before.WeatherDescriptions,
+		Humidity:            linearInt(before.Humidity.Value(), after.Humidity.Value()),
+		Visibility:          w_types.Float64OrString(linear(before.Visibility.Value(), after.Visibility.Value())),
+		Pressure:            w_types.Float64OrString(linear(before.Pressure.Value(), after.Pressure.Value())),
+		Cloudcover:          linearInt(before.Cloudcover.Value(), after.Cloudcover.Value()),
+		Heatindex:           linearNull(before.Heatindex, after.Heatindex),
+		Dewpoint:            linearNull(before.Dewpoint, after.Dewpoint),
+		Windchill:           linearNull(before.Windchill, after.Windchill),
+		Windgust:            linearNull(before.Windgust, after.Windgust),
+		FeelsLike:           w_types.Float64OrString(linear(before.FeelsLike.Value(), after.FeelsLike.Value())),
+		Interpolated:        true,
+		units:               before.units,
+	}
+	hourly.Time = go_types.Int64String(minute/60*100 + minute%60)
+
+	if precip == PrecipGapFillCarryForward {
+		hourly.Precip = before.Precip
+	}
+
+	return hourly
+}
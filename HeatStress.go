@@ -0,0 +1,104 @@
+package weatherstack
+
+import "math"
+
+// Humidex computes the Canadian humidex (°C) from temperature (°C) and relative humidity (%), the vapour pressure
+// being derived with the Magnus formula
+func Humidex(tempC, humidity float64) float64 {
+	return tempC + 5.0/9.0*(vapourPressure(tempC, humidity)-10)
+}
+
+// WetBulb computes the wet-bulb temperature (°C) from temperature (°C) and relative humidity (%) at sea level pressure
+// using the approximation of Stull (2011), accurate within 1°C.
+// ok is false outside the formula's domain (humidity below 5% or above 99%, temperature below -20°C or above 50°C).
+func WetBulb(tempC, humidity float64) (wetBulb float64, ok bool) {
+	if humidity < 5 || humidity > 99 || tempC < -20 || tempC > 50 {
+		return tempC, false
+	}
+
+	t, rh := tempC, humidity
+
+	return t*math.Atan(0.151977*math.Sqrt(rh+8.313659)) +
+		math.Atan(t+rh) - math.Atan(rh-1.676331) +
+		0.00391838*math.Pow(rh, 1.5)*math.Atan(0.023101*rh) -
+		4.686035, true
+}
+
+// WBGT approximates the wet-bulb globe temperature (°C) in the shade from temperature (°C) and relative humidity (%)
+// using the formula of the Australian Bureau of Meteorology. It assumes moderate wind and no direct sun,
+// underestimating WBGT in full sun by up to ~3°C.
+func WBGT(tempC, humidity float64) float64 {
+	return 0.567*tempC + 0.393*vapourPressure(tempC, humidity) + 3.94
+}
+
+// vapourPressure computes the vapour pressure (hPa) from temperature (°C) and relative humidity (%)
+func vapourPressure(tempC, humidity float64) float64 {
+	return humidity / 100 * 6.105 * math.Exp(17.27*tempC/(237.7+tempC))
+}
+
+type HeatStressFlag string
+
+// heat stress flags by WBGT, following the flag conditions of the US military (TB MED 507)
+const (
+	HeatStressFlagNone   HeatStressFlag = "none"   // below 27.8°C
+	HeatStressFlagGreen  HeatStressFlag = "green"  // 27.8°C up to 29.4°C
+	HeatStressFlagYellow HeatStressFlag = "yellow" // 29.4°C up to 31.1°C
+	HeatStressFlagRed    HeatStressFlag = "red"    // 31.1°C up to 32.2°C
+	HeatStressFlagBlack  HeatStressFlag = "black"  // 32.2°C and above
+)
+
+// HeatStressFlagOf returns the heat stress flag of a WBGT (°C)
+func HeatStressFlagOf(wbgtC float64) HeatStressFlag {
+	switch {
+	case wbgtC >= 32.2:
+		return HeatStressFlagBlack
+	case wbgtC >= 31.1:
+		return HeatStressFlagRed
+	case wbgtC >= 29.4:
+		return HeatStressFlagYellow
+	case wbgtC >= 27.8:
+		return HeatStressFlagGreen
+	}
+
+	return HeatStressFlagNone
+}
+
+// Humidex computes the humidex (°C) from Temperature and Humidity
+func (h HourlyWeather) Humidex() float64 {
+	return Humidex(h.TemperatureValue().Celsius(), float64(h.Humidity.Value()))
+}
+
+// WetBulb computes the wet-bulb temperature (°C) from Temperature and Humidity, see WetBulb
+func (h HourlyWeather) WetBulb() (float64, bool) {
+	return WetBulb(h.TemperatureValue().Celsius(), float64(h.Humidity.Value()))
+}
+
+// WBGT approximates the wet-bulb globe temperature (°C) in the shade from Temperature and Humidity, see WBGT
+func (h HourlyWeather) WBGT() float64 {
+	return WBGT(h.TemperatureValue().Celsius(), float64(h.Humidity.Value()))
+}
+
+// HeatStressFlag returns the heat stress flag of WBGT
+func (h HourlyWeather) HeatStressFlag() HeatStressFlag {
+	return HeatStressFlagOf(h.WBGT())
+}
+
+// Humidex computes the humidex (°C) from Temperature and Humidity
+func (c CurrentWeather) Humidex() float64 {
+	return Humidex(c.TemperatureValue().Celsius(), float64(c.Humidity.Value()))
+}
+
+// WetBulb computes the wet-bulb temperature (°C) from Temperature and Humidity, see WetBulb
+func (c CurrentWeather) WetBulb() (float64, bool) {
+	return WetBulb(c.TemperatureValue().Celsius(), float64(c.Humidity.Value()))
+}
+
+// WBGT approximates the wet-bulb globe temperature (°C) in the shade from Temperature and Humidity, see WBGT
+func (c CurrentWeather) WBGT() float64 {
+	return WBGT(c.TemperatureValue().Celsius(), float64(c.Humidity.Value()))
+}
+
+// HeatStressFlag returns the heat stress flag of WBGT
+func (c CurrentWeather) HeatStressFlag() HeatStressFlag {
+	return HeatStressFlagOf(c.WBGT())
+}
@@ -0,0 +1,96 @@
+package weatherstack
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHumidex(t *testing.T) {
+	// values of the humidex table of Environment Canada
+	tests := []struct {
+		tempC    float64
+		humidity float64
+		want     float64
+	}{
+		{30, 70, 41},
+		{35, 50, 45},
+	}
+
+	for _, test := range tests {
+		if got := Humidex(test.tempC, test.humidity); math.Abs(got-test.want) > 0.5 {
+			t.Errorf("Humidex(%v, %v) = %.1f, want %v", test.tempC, test.humidity, got, test.want)
+		}
+	}
+}
+
+func TestWetBulb(t *testing.T) {
+	tests := []struct {
+		name     string
+		tempC    float64
+		humidity float64
+		want     float64
+		wantOK   bool
+	}{
+		{"Stull (2011) example", 20, 50, 13.7, true},
+		{"saturated", 25, 99, 24.9, true},
+		{"minimum humidity", 30, 5, 10.77, true},
+		{"minimum temperature", -20, 50, -20.7, true},
+		{"maximum temperature", 50, 20, 29.51, true},
+		{"humidity too low", 30, 4.9, 30, false},
+		{"humidity too high", 25, 99.1, 25, false},
+		{"temperature too low", -20.1, 50, -20.1, false},
+		{"temperature too high", 50.1, 20, 50.1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := WetBulb(test.tempC, test.humidity)
+			if ok != test.wantOK || math.Abs(got-test.want) > 0.05 {
+				t.Errorf("WetBulb(%v, %v) = %.2f, %v, want %v, %v", test.tempC, test.humidity, got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestWBGT(t *testing.T) {
+	// 0.567 T + 0.393 e + 3.94, e being the vapour pressure in hPa
+	tests := []struct {
+		tempC    float64
+		humidity float64
+		want     float64
+	}{
+		{30, 70, 32.6},
+		{25, 50, 24.3},
+		{35, 40, 32.6},
+	}
+
+	for _, test := range tests {
+		if got := WBGT(test.tempC, test.humidity); math.Abs(got-test.want) > 0.05 {
+			t.Errorf("WBGT(%v, %v) = %.2f, want %v", test.tempC, test.humidity, got, test.want)
+		}
+	}
+}
+
+func TestHeatStressFlagOf(t *testing.T) {
+	tests := []struct {
+		wbgtC float64
+		want  HeatStressFlag
+	}{
+		{20, HeatStressFlagNone},
+		{27.79, HeatStressFlagNone},
+		{27.8, HeatStressFlagGreen},
+		{29.39, HeatStressFlagGreen},
+		{29.4, HeatStressFlagYellow},
+		{31.09, HeatStressFlagYellow},
+		{31.1, HeatStressFlagRed},
+		{32.19, HeatStressFlagRed},
+		{32.2, HeatStressFlagBlack},
+		{40, HeatStressFlagBlack},
+	}
+
+	for _, test := range tests {
+		if got := HeatStressFlagOf(test.wbgtC); got != test.want {
+			t.Errorf("HeatStressFlagOf(%v) = %s, want %s", test.wbgtC, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package weatherstack
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"time"
@@ -12,75 +13,161 @@ import (
 )
 
 type HistoricalResponse struct {
-	Request    Request            `json:"request"`
-	Location   Location           `json:"location"`
-	Current    CurrentWeather     `json:"current"`
-	Historical map[string]Weather `json:"historical"`
+	Request     Request            `json:"request"`
+	Location    Location           `json:"location"`
+	Current     CurrentWeather     `json:"current"`
+	Historical  map[string]Weather `json:"historical"`
+	RawResponse []byte             `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true
+	Meta        *ResponseMeta      `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true and the response was not cached
 }
 
 type GetHistoricalWeatherConfig struct {
-	Query     string
-	StartDate civil.Date
-	EndDate   *civil.Date
-	Hourly    *Hourly
-	Interval  *Interval
-	Units     *Units
-	Language  *string
+	Query           string
+	Coordinates     *Coordinates // alternative to Query
+	StartDate       civil.Date
+	EndDate         *civil.Date
+	Hourly          *Hourly
+	Interval        *Interval
+	Units           *Units
+	Language        *Language
+	HourStart       *int // hour code (0, 100, ..., 2300) of the first hour of historical_time_frame
+	HourEnd         *int // hour code (0, 100, ..., 2300) of the last hour of historical_time_frame
+	BaseURLOverride *string
+	ExtraParams     url.Values     // merged into the query string, overriding parameters with the same name
+	Timeout         *time.Duration // of the call including retries, in addition to the deadline of the context
 }
 
-func (service *Service) GetHistoricalWeather(config GetHistoricalWeatherConfig) (*HistoricalResponse, *errortools.Error) {
-	values := url.Values{}
+func (service *Service) GetHistoricalWeather(config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalWeatherWithContext(context.Background(), config)
+}
 
-	startDate := utilities.DateToTime(config.StartDate)
+func (service *Service) GetHistoricalWeatherWithContext(ctx context.Context, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
 
-	if config.EndDate == nil {
-		values.Add("historical_date", startDate.Format(dateFormat))
-	} else {
-		endDate := utilities.DateToTime(*config.EndDate)
+	_url, e := service.historicalURL(config)
+	if e != nil {
+		return nil, newRequestError(e, nil)
+	}
 
-		if startDate.After(endDate) {
-			return nil, errortools.ErrorMessage("StartDate must be smaller or equal to EndDate.")
-		}
+	historicalResponse := HistoricalResponse{}
 
-		maxEndDate := startDate.Add(time.Duration(MaxDaysPerCall-1) * 24 * time.Hour)
+	requestConfig := go_http.RequestConfig{
+		URL:           _url,
+		ResponseModel: &historicalResponse,
+	}
 
-		if endDate.After(maxEndDate) {
-			return nil, errortools.ErrorMessage("Maximum time frame of 60 days exceeded.")
-		}
+	_, _, requestError := service.get(ctx, &requestConfig)
+	if requestError != nil {
+		return nil, requestError
+	}
 
-		values.Add("historical_date_start", startDate.Format(dateFormat))
-		values.Add("historical_date_end", endDate.Format(dateFormat))
+	e = historicalResponse.Validate()
+	if e != nil {
+		return nil, newRequestError(e, nil)
 	}
 
-	values.Add("query", config.Query)
+	service.locations.remember(config.Query, historicalResponse.Location)
+
+	return &historicalResponse, nil
+}
+
+// GetHistoricalWeatherByCoordinates requests the historical weather for the coordinates lat, lon, config.Query and
+// config.Coordinates are ignored
+func (service *Service) GetHistoricalWeatherByCoordinates(lat float64, lon float64, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalWeatherByCoordinatesWithContext(context.Background(), lat, lon, config)
+}
+
+func (service *Service) GetHistoricalWeatherByCoordinatesWithContext(ctx context.Context, lat float64, lon float64, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	config.Query = ""
+	config.Coordinates = &Coordinates{Lat: lat, Lon: lon}
+
+	return service.GetHistoricalWeatherWithContext(ctx, config)
+}
+
+func (service *Service) historicalURL(config GetHistoricalWeatherConfig) (string, *errortools.Error) {
+	config.Query, config.Coordinates = service.locations.rewrite(config.Query, config.Coordinates)
+	config.Units = service.unitsOrDefault(config.Units)
+	config.Hourly = service.hourlyOrImplied(config.Hourly, config.Interval)
+	config.Language = service.languageOrDefault(config.Language)
+
+	e := config.Validate()
+	if e != nil {
+		return "", e
+	}
 
-	if config.Hourly != nil {
-		values.Add("hourly", fmt.Sprintf("%v", int64(*config.Hourly)))
+	query, e := resolveQuery(config.Query, config.Coordinates)
+	if e != nil {
+		return "", e
 	}
 
-	if config.Interval != nil {
-		values.Add("interval", fmt.Sprintf("%v", int64(*config.Interval)))
+	values := baseValues(query, config.Units, config.Hourly, config.Interval, config.Language)
+
+	startDate := utilities.DateToTime(config.StartDate)
+
+	if config.EndDate == nil {
+		values.Add("historical_date", startDate.Format(dateFormat))
+	} else {
+		values.Add("historical_date_start", startDate.Format(dateFormat))
+		values.Add("historical_date_end", utilities.DateToTime(*config.EndDate).Format(dateFormat))
 	}
 
-	if config.Units != nil {
-		values.Add("units", fmt.Sprintf("%s", string(*config.Units)))
+	if config.HourStart != nil {
+		values.Add("historical_time_frame", fmt.Sprintf("%v-%v", *config.HourStart, *config.HourEnd))
 	}
 
-	if config.Language != nil {
-		values.Add("language", *config.Language)
+	mergeExtraParams(values, config.ExtraParams)
+
+	return service.urlWithOverride(config.BaseURLOverride, fmt.Sprintf("historical?%s", values.Encode()))
+}
+
+// Validate checks the config locally, returning all violations at once
+func (config GetHistoricalWeatherConfig) Validate() *errortools.Error {
+	var v violations
+
+	v.add(validateQueryOrCoordinates(config.Query, config.Coordinates))
+	v.add(validateTimeout(config.Timeout))
+	v.add(validateParameters(config.Units, config.Interval, config.Language))
+	v.add(validateHourlyInterval(config.Hourly, config.Interval))
+
+	startDate := utilities.DateToTime(config.StartDate)
+	today := civil.DateOf(time.Now().UTC())
+
+	if !config.StartDate.IsValid() {
+		v.addf("Invalid StartDate: %s", config.StartDate)
+	} else if config.StartDate.After(today) {
+		v.addf("StartDate %s is in the future", config.StartDate)
 	}
 
-	historicalResponse := HistoricalResponse{}
+	if config.EndDate != nil {
+		endDate := utilities.DateToTime(*config.EndDate)
 
-	requestConfig := go_http.RequestConfig{
-		URL:           service.url(fmt.Sprintf("historical?%s", values.Encode())),
-		ResponseModel: &historicalResponse,
+		if !config.EndDate.IsValid() {
+			v.addf("Invalid EndDate: %s", *config.EndDate)
+		} else if config.EndDate.After(today) {
+			v.addf("EndDate %s is in the future", *config.EndDate)
+		}
+
+		if startDate.After(endDate) {
+			v.addf("StartDate must be smaller or equal to EndDate")
+		} else if endDate.After(startDate.Add(time.Duration(MaxDaysPerCall-1) * 24 * time.Hour)) {
+			v.addf("Maximum time frame of %v days exceeded", MaxDaysPerCall)
+		}
 	}
 
-	_, _, e := service.get(&requestConfig)
-	if e != nil {
-		return nil, e
+	if config.HourStart != nil || config.HourEnd != nil {
+		if config.HourStart == nil || config.HourEnd == nil {
+			v.addf("HourStart and HourEnd must both be set")
+		} else if !isValidHourCode(*config.HourStart) || !isValidHourCode(*config.HourEnd) {
+			v.addf("HourStart and HourEnd must be hour codes 0, 100, ..., 2300")
+		} else if *config.HourStart > *config.HourEnd {
+			v.addf("HourStart must be smaller or equal to HourEnd")
+		}
 	}
 
-	return &historicalResponse, nil
+	return v.error()
+}
+
+func isValidHourCode(hourCode int) bool {
+	return hourCode >= 0 && hourCode <= 2300 && hourCode%100 == 0
 }
@@ -0,0 +1,34 @@
+package weatherstack
+
+import (
+	"context"
+
+	"cloud.google.com/go/civil"
+)
+
+// GetAstro returns the astronomical data (sunrise, sunset, moon) per date from start through end, keyed by date (YYYY-MM-DD).
+// Hourly data is not requested, ranges exceeding MaxDaysPerCall days are split over multiple calls.
+func (service *Service) GetAstro(query string, start civil.Date, end civil.Date) (map[string]Astro, *RequestError) {
+	return service.GetAstroWithContext(context.Background(), query, start, end)
+}
+
+func (service *Service) GetAstroWithContext(ctx context.Context, query string, start civil.Date, end civil.Date) (map[string]Astro, *RequestError) {
+	hourly := HourlyOff
+
+	historicalResponse, e := service.GetHistoricalWeatherRangeWithContext(ctx, GetHistoricalWeatherConfig{
+		Query:     query,
+		StartDate: start,
+		EndDate:   &end,
+		Hourly:    &hourly,
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	astro := make(map[string]Astro, len(historicalResponse.Historical))
+	for date, weather := range historicalResponse.Historical {
+		astro[date] = weather.Astro
+	}
+
+	return astro, nil
+}
@@ -0,0 +1,127 @@
+package weatherstack
+
+import (
+	"context"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// GetHistoricalWeatherBatch requests the historical weather for each config using at most concurrency concurrent requests.
+// The responses and errors are aligned to configs. The first error cancels the requests not yet completed,
+// which then get a context canceled error.
+func (service *Service) GetHistoricalWeatherBatch(configs []GetHistoricalWeatherConfig, concurrency int) ([]*HistoricalResponse, []*RequestError) {
+	return service.GetHistoricalWeatherBatchWithContext(context.Background(), configs, concurrency)
+}
+
+func (service *Service) GetHistoricalWeatherBatchWithContext(ctx context.Context, configs []GetHistoricalWeatherConfig, concurrency int) ([]*HistoricalResponse, []*RequestError) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	historicalResponses := make([]*HistoricalResponse, len(configs))
+	errs := make([]*RequestError, len(configs))
+
+	runConcurrently(len(configs), concurrency, func(i int) {
+		historicalResponse, e := service.GetHistoricalWeatherWithContext(ctx, configs[i])
+		if e != nil {
+			errs[i] = e
+			cancel()
+			return
+		}
+
+		historicalResponses[i] = historicalResponse
+	})
+
+	return historicalResponses, errs
+}
+
+type BatchOptions struct {
+	Concurrency       int     // maximum number of concurrent requests, defaults to 4
+	RequestsPerSecond float64 // maximum rate at which requests are started, unlimited if 0
+	StopOnError       bool    // cancel the requests not yet completed after the first error
+}
+
+// HistoricalWeatherResult is the outcome of a single config of GetHistoricalWeatherBatchByQuery
+type HistoricalWeatherResult struct {
+	Query    string              // Query of the config or the query of its Coordinates, empty if it could not be resolved
+	Response *HistoricalResponse // nil if Error is set
+	Error    *RequestError
+}
+
+// GetHistoricalWeatherBatchByQuery requests the historical weather for each config through a pool of options.Concurrency workers.
+// The results are aligned to configs, so every config has its own result. Configs sharing a query are not requested,
+// each of them gets an error. Unless options.StopOnError is set all configs are requested regardless of errors.
+func (service *Service) GetHistoricalWeatherBatchByQuery(configs []GetHistoricalWeatherConfig, options BatchOptions) []HistoricalWeatherResult {
+	return service.GetHistoricalWeatherBatchByQueryWithContext(context.Background(), configs, options)
+}
+
+func (service *Service) GetHistoricalWeatherBatchByQueryWithContext(ctx context.Context, configs []GetHistoricalWeatherConfig, options BatchOptions) []HistoricalWeatherResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]HistoricalWeatherResult, len(configs))
+	occurrences := make(map[string]int)
+
+	for i, config := range configs {
+		query, e := resolveQuery(config.Query, config.Coordinates)
+		if e != nil {
+			results[i].Error = newRequestError(e, nil)
+			continue
+		}
+
+		if query == "" {
+			results[i].Error = newRequestError(errortools.ErrorMessage("Query not provided"), nil)
+			continue
+		}
+
+		results[i].Query = query
+		occurrences[query]++
+	}
+
+	batch := []int{}
+
+	for i, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		if occurrences[result.Query] > 1 {
+			results[i].Error = newRequestError(errortools.ErrorMessagef("Query %s occurs more than once", result.Query), nil)
+			continue
+		}
+
+		batch = append(batch, i)
+	}
+
+	throttle, stop := newThrottle(options.RequestsPerSecond)
+	defer stop()
+
+	runConcurrently(len(batch), options.Concurrency, func(i int) {
+		throttle(ctx)
+
+		result := &results[batch[i]]
+		result.Response, result.Error = service.GetHistoricalWeatherWithContext(ctx, configs[batch[i]])
+		if result.Error != nil && options.StopOnError {
+			cancel()
+		}
+	})
+
+	return results
+}
+
+// newThrottle returns a function blocking until the next of requestsPerSecond ticks (or until ctx is done),
+// unlimited if requestsPerSecond is 0. stop releases the ticker.
+func newThrottle(requestsPerSecond float64) (throttle func(ctx context.Context), stop func()) {
+	if requestsPerSecond <= 0 {
+		return func(ctx context.Context) {}, func() {}
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / requestsPerSecond))
+
+	return func(ctx context.Context) {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+		}
+	}, ticker.Stop
+}
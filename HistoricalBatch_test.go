@@ -0,0 +1,49 @@
+package weatherstack
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestGetHistoricalWeatherBatchByQueryKeepsEveryFailure(t *testing.T) {
+	var requests int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"success":false,"error":{"code":615,"type":"request_failed","info":"Your API request failed."}}`))
+	}, ServiceConfig{})
+
+	startDate := civil.Date{Year: 2021, Month: 9, Day: 9}
+	configs := []GetHistoricalWeatherConfig{
+		{Query: "Amsterdam", StartDate: startDate},
+		{StartDate: startDate},
+		{Query: "Paris", StartDate: startDate},
+		{StartDate: startDate},
+		{Query: "Paris", StartDate: startDate},
+	}
+
+	results := service.GetHistoricalWeatherBatchByQuery(configs, BatchOptions{})
+	if len(results) != len(configs) {
+		t.Fatalf("got %v results, want %v", len(results), len(configs))
+	}
+
+	for i, result := range results {
+		if result.Error == nil || result.Response != nil {
+			t.Errorf("result %v = %+v, want an error", i, result)
+		}
+	}
+
+	if apiError, ok := WeatherstackErrorOf(results[0].Error); !ok || apiError.Code != 615 || results[0].Query != "Amsterdam" {
+		t.Errorf("result 0 = %+v, want the error of the API for Amsterdam", results[0])
+	}
+	for _, i := range []int{2, 4} {
+		if _, ok := WeatherstackErrorOf(results[i].Error); ok || results[i].Query != "Paris" {
+			t.Errorf("result %v = %+v, want a duplicate query error for Paris", i, results[i])
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %v requests, want 1", requests)
+	}
+}
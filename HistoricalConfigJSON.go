@@ -0,0 +1,102 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// historicalWeatherConfigJSON is the serialized form of GetHistoricalWeatherConfig.
+// Dates are formatted as YYYY-MM-DD and unset optional fields are kept as null.
+type historicalWeatherConfigJSON struct {
+	Query           string           `json:"query"`
+	Coordinates     *coordinatesJSON `json:"coordinates"`
+	StartDate       civil.Date       `json:"start_date"`
+	EndDate         *civil.Date      `json:"end_date"`
+	Hourly          *Hourly          `json:"hourly"`
+	Interval        *Interval        `json:"interval"`
+	Units           *Units           `json:"units"`
+	Language        *Language        `json:"language"`
+	HourStart       *int             `json:"hour_start"`
+	HourEnd         *int             `json:"hour_end"`
+	BaseURLOverride *string          `json:"base_url_override"`
+	ExtraParams     url.Values       `json:"extra_params"`
+	Timeout         *time.Duration   `json:"timeout"` // nanoseconds
+}
+
+type coordinatesJSON struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func (config GetHistoricalWeatherConfig) MarshalJSON() ([]byte, error) {
+	configJSON := historicalWeatherConfigJSON{
+		Query:           config.Query,
+		StartDate:       config.StartDate,
+		EndDate:         config.EndDate,
+		Hourly:          config.Hourly,
+		Interval:        config.Interval,
+		Units:           config.Units,
+		Language:        config.Language,
+		HourStart:       config.HourStart,
+		HourEnd:         config.HourEnd,
+		BaseURLOverride: config.BaseURLOverride,
+		ExtraParams:     config.ExtraParams,
+		Timeout:         config.Timeout,
+	}
+
+	if config.Coordinates != nil {
+		configJSON.Coordinates = &coordinatesJSON{Lat: config.Coordinates.Lat, Lon: config.Coordinates.Lon}
+	}
+
+	return json.Marshal(configJSON)
+}
+
+// UnmarshalJSON reconstructs a config serialized by MarshalJSON and fails if it is not a valid request
+func (config *GetHistoricalWeatherConfig) UnmarshalJSON(b []byte) error {
+	configJSON := historicalWeatherConfigJSON{}
+
+	err := json.Unmarshal(b, &configJSON)
+	if err != nil {
+		return err
+	}
+
+	result := GetHistoricalWeatherConfig{
+		Query:           configJSON.Query,
+		StartDate:       configJSON.StartDate,
+		EndDate:         configJSON.EndDate,
+		Hourly:          configJSON.Hourly,
+		Interval:        configJSON.Interval,
+		Units:           configJSON.Units,
+		Language:        configJSON.Language,
+		HourStart:       configJSON.HourStart,
+		HourEnd:         configJSON.HourEnd,
+		BaseURLOverride: configJSON.BaseURLOverride,
+		ExtraParams:     configJSON.ExtraParams,
+		Timeout:         configJSON.Timeout,
+	}
+
+	if configJSON.Coordinates != nil {
+		result.Coordinates = &Coordinates{Lat: configJSON.Coordinates.Lat, Lon: configJSON.Coordinates.Lon}
+	}
+
+	if !result.StartDate.IsValid() {
+		return errors.New("invalid start_date")
+	}
+
+	if result.EndDate != nil && !result.EndDate.IsValid() {
+		return errors.New("invalid end_date")
+	}
+
+	e := result.Validate()
+	if e != nil {
+		return errors.New(e.Message())
+	}
+
+	*config = result
+
+	return nil
+}
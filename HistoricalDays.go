@@ -0,0 +1,96 @@
+package weatherstack
+
+import (
+	"sort"
+
+	"cloud.google.com/go/civil"
+)
+
+// NearestDate returns the day in the response closest to target.
+// When two days are equally close, the earlier one is returned.
+// ok is false if the response has no (parseable) days.
+func (r *HistoricalResponse) NearestDate(target civil.Date) (civil.Date, *HistoricalWeather, bool) {
+	var nearestDate civil.Date
+	var nearestWeather *HistoricalWeather
+	nearestDistance := -1
+
+	for key := range r.Historical {
+		date, err := civil.ParseDate(key)
+		if err != nil {
+			continue
+		}
+
+		distance := target.DaysSince(date)
+		if distance < 0 {
+			distance = -distance
+		}
+
+		if nearestDistance == -1 || distance < nearestDistance || (distance == nearestDistance && date.Before(nearestDate)) {
+			weather := r.Historical[key]
+
+			nearestDate = date
+			nearestWeather = &weather
+			nearestDistance = distance
+		}
+	}
+
+	return nearestDate, nearestWeather, nearestWeather != nil
+}
+
+// IncompleteDays returns the dates (sorted) whose hourly records are fewer than the interval implies
+func (r *HistoricalResponse) IncompleteDays(interval Interval) []string {
+	incompleteDays := []string{}
+
+	for date, day := range r.Historical {
+		if !day.HourlyComplete(interval) {
+			incompleteDays = append(incompleteDays, date)
+		}
+	}
+
+	sort.Strings(incompleteDays)
+
+	return incompleteDays
+}
+
+// Days returns the days in chronological order, YYYY-MM-DD keys sorting chronologically
+func (r *HistoricalResponse) Days() []HistoricalWeather {
+	dates := make([]string, 0, len(r.Historical))
+	for date := range r.Historical {
+		dates = append(dates, date)
+	}
+
+	sort.Strings(dates)
+
+	days := make([]HistoricalWeather, len(dates))
+	for i, date := range dates {
+		days[i] = r.Historical[date]
+	}
+
+	return days
+}
+
+// Day returns the day for date, nil if the response does not contain it
+func (r *HistoricalResponse) Day(date civil.Date) *HistoricalWeather {
+	day, ok := r.Historical[date.String()]
+	if !ok {
+		return nil
+	}
+
+	return &day
+}
+
+// sortedDates returns the parseable date keys of days in chronological order
+func sortedDates(days map[string]Weather) []civil.Date {
+	dates := make([]civil.Date, 0, len(days))
+	for key := range days {
+		if date, err := civil.ParseDate(key); err == nil {
+			dates = append(dates, date)
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates
+}
@@ -0,0 +1,23 @@
+package weatherstack
+
+// HasData reports whether Weatherstack actually returned data for the day, as for very old dates or some locations
+// it returns an entry with empty or zero values instead of an error.
+// The entry is considered empty when either
+//   - both Date and DateEpoch are empty, or
+//   - there are no hourly records, no sunrise and MinTemp, MaxTemp, AvgTemp, TotalSnow, SunHour and UVIndex are all zero.
+//
+// A real day is only mistaken for empty if it matches the second case, e.g. a polar night averaging exactly 0°C
+// requested without hourly data.
+func (h HistoricalWeather) HasData() bool {
+	if h.DateEpoch == 0 && h.Date.Value().IsZero() {
+		return false
+	}
+
+	if len(h.Hourly) == 0 && h.Astro.Sunrise.TimeString == "" &&
+		h.MinTemp == 0 && h.MaxTemp == 0 && h.AvgTemp == 0 &&
+		h.TotalSnow == 0 && h.SunHour == 0 && h.UVIndex == 0 {
+		return false
+	}
+
+	return true
+}
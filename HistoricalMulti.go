@@ -0,0 +1,206 @@
+package weatherstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	go_http "github.com/leapforce-libraries/go_http"
+)
+
+// default number of concurrent requests when bulk queries are not supported
+const defaultConcurrency int = 4
+
+type GetHistoricalWeatherMultiConfig struct {
+	Queries     []string
+	Config      GetHistoricalWeatherConfig // Query is ignored
+	Concurrency int                        // concurrent requests when bulk queries are not supported, defaults to 4
+}
+
+// GetHistoricalWeatherMulti requests the historical weather for multiple queries, returning the responses keyed by query.
+// A single bulk request (queries separated by ";") is tried first. If the plan does not support bulk queries,
+// one request per query is issued concurrently. Then failures are partial: the errors are returned keyed by query
+// and the results of the other queries are still returned. Any other error of the bulk request is returned for all queries.
+// Duplicate queries are requested once, a single query is requested through GetHistoricalWeather.
+func (service *Service) GetHistoricalWeatherMulti(config GetHistoricalWeatherMultiConfig) (map[string]*HistoricalResponse, map[string]*RequestError) {
+	return service.GetHistoricalWeatherMultiWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalWeatherMultiWithContext(ctx context.Context, config GetHistoricalWeatherMultiConfig) (map[string]*HistoricalResponse, map[string]*RequestError) {
+	config.Queries = uniqueQueries(config.Queries)
+
+	if len(config.Queries) == 0 {
+		return map[string]*HistoricalResponse{}, map[string]*RequestError{}
+	}
+
+	if len(config.Queries) == 1 {
+		return service.getHistoricalWeatherConcurrently(ctx, config)
+	}
+
+	responses, apiError, e := service.getHistoricalWeatherBulk(ctx, config)
+	if e == nil {
+		return responses, map[string]*RequestError{}
+	}
+
+	if apiError == nil || apiError.Code != ErrorCodeBulkQueriesNotSupported {
+		errs := make(map[string]*RequestError, len(config.Queries))
+		for _, query := range config.Queries {
+			errs[query] = e
+		}
+		return map[string]*HistoricalResponse{}, errs
+	}
+
+	return service.getHistoricalWeatherConcurrently(ctx, config)
+}
+
+func (service *Service) getHistoricalWeatherBulk(ctx context.Context, config GetHistoricalWeatherMultiConfig) (map[string]*HistoricalResponse, *WeatherstackError, *RequestError) {
+	// the URL is built for the first query, each query being validated as a single location query
+	// so that none can contain the ';' separating them
+	for _, query := range config.Queries {
+		if e := validateQuery(query); e != nil {
+			return nil, nil, newRequestError(e, nil)
+		}
+	}
+
+	historicalConfig := config.Config
+	historicalConfig.Query = config.Queries[0]
+	historicalConfig.Coordinates = nil
+
+	_url, e := service.historicalURL(historicalConfig)
+	if e != nil {
+		return nil, nil, newRequestError(e, nil)
+	}
+
+	_url, e = withQuery(_url, strings.Join(config.Queries, ";"))
+	if e != nil {
+		return nil, nil, newRequestError(e, nil)
+	}
+
+	historicalResponses := bulkHistoricalResponses{}
+
+	requestConfig := go_http.RequestConfig{
+		URL:           _url,
+		ResponseModel: &historicalResponses,
+	}
+
+	_, _, requestError := service.get(ctx, &requestConfig)
+	if requestError != nil {
+		return nil, weatherstackError(&requestConfig), requestError
+	}
+
+	// responses are returned in the order of the queries, so they can only be matched if there is one per query
+	if len(historicalResponses) != len(config.Queries) {
+		return nil, nil, newRequestError(errortools.ErrorMessagef("Bulk request returned %v responses for %v queries", len(historicalResponses), len(config.Queries)), nil)
+	}
+
+	responses := make(map[string]*HistoricalResponse, len(config.Queries))
+	for i := range historicalResponses {
+		historicalResponses[i].setUnits()
+		responses[config.Queries[i]] = &historicalResponses[i]
+	}
+
+	return responses, nil, nil
+}
+
+// bulkHistoricalResponses decodes both an array of responses and the single object returned if the queries resolve to one location
+type bulkHistoricalResponses []HistoricalResponse
+
+func (responses *bulkHistoricalResponses) UnmarshalJSON(b []byte) error {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '{' {
+		historicalResponse := HistoricalResponse{}
+		err := json.Unmarshal(trimmed, &historicalResponse)
+		if err != nil {
+			return err
+		}
+		*responses = bulkHistoricalResponses{historicalResponse}
+
+		return nil
+	}
+
+	return json.Unmarshal(b, (*[]HistoricalResponse)(responses))
+}
+
+// uniqueQueries returns queries without duplicates, in their original order
+func uniqueQueries(queries []string) []string {
+	seen := make(map[string]bool, len(queries))
+	unique := make([]string, 0, len(queries))
+
+	for _, query := range queries {
+		if !seen[query] {
+			seen[query] = true
+			unique = append(unique, query)
+		}
+	}
+
+	return unique
+}
+
+// withQuery returns _url with the query parameter set to query
+func withQuery(_url string, query string) (string, *errortools.Error) {
+	parsedURL, err := url.Parse(_url)
+	if err != nil {
+		return "", errortools.ErrorMessage(err)
+	}
+
+	values := parsedURL.Query()
+	values.Set("query", query)
+	parsedURL.RawQuery = values.Encode()
+
+	return parsedURL.String(), nil
+}
+
+func (service *Service) getHistoricalWeatherConcurrently(ctx context.Context, config GetHistoricalWeatherMultiConfig) (map[string]*HistoricalResponse, map[string]*RequestError) {
+	responses := make(map[string]*HistoricalResponse, len(config.Queries))
+	errs := make(map[string]*RequestError)
+
+	mutex := sync.Mutex{}
+
+	runConcurrently(len(config.Queries), config.Concurrency, func(i int) {
+		historicalConfig := config.Config
+		historicalConfig.Query = config.Queries[i]
+
+		historicalResponse, e := service.GetHistoricalWeatherWithContext(ctx, historicalConfig)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if e != nil {
+			errs[config.Queries[i]] = e
+			return
+		}
+		responses[config.Queries[i]] = historicalResponse
+	})
+
+	return responses, errs
+}
+
+// runConcurrently calls do for 0 through n-1 using at most concurrency goroutines (defaults to 4 if not positive)
+func runConcurrently(n int, concurrency int, do func(i int)) {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	indexes := make(chan int)
+	waitGroup := sync.WaitGroup{}
+
+	for worker := 0; worker < concurrency && worker < n; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for i := range indexes {
+				do(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	waitGroup.Wait()
+}
@@ -0,0 +1,101 @@
+package weatherstack
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// GetHistoricalPeriodConfig configures the named period methods.
+// Periods are calendar based: last week is the ISO week (Monday through Sunday)
+// before the current one, last month is the calendar month before the current one.
+// Both boundaries are inclusive.
+type GetHistoricalPeriodConfig struct {
+	Query           string
+	Hourly          *Hourly
+	Interval        *Interval
+	Units           *Units
+	Language        *Language
+	TimeZone        *time.Location // zone used to determine the current date, defaults to the time zone of the location
+	BaseURLOverride *string
+}
+
+func (service *Service) GetHistoricalLastWeek(config GetHistoricalPeriodConfig) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalLastWeekWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalLastWeekWithContext(ctx context.Context, config GetHistoricalPeriodConfig) (*HistoricalResponse, *RequestError) {
+	return service.getHistoricalPeriod(ctx, config, lastWeek)
+}
+
+func (service *Service) GetHistoricalLastMonth(config GetHistoricalPeriodConfig) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalLastMonthWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalLastMonthWithContext(ctx context.Context, config GetHistoricalPeriodConfig) (*HistoricalResponse, *RequestError) {
+	return service.getHistoricalPeriod(ctx, config, lastMonth)
+}
+
+func lastWeek(today civil.Date) (civil.Date, civil.Date) {
+	// weekday with Monday = 0
+	weekday := (int(today.In(time.UTC).Weekday()) + 6) % 7
+	startDate := today.AddDays(-weekday - 7)
+
+	return startDate, startDate.AddDays(6)
+}
+
+func lastMonth(today civil.Date) (civil.Date, civil.Date) {
+	firstOfMonth := civil.Date{Year: today.Year, Month: today.Month, Day: 1}
+	endDate := firstOfMonth.AddDays(-1)
+
+	return civil.Date{Year: endDate.Year, Month: endDate.Month, Day: 1}, endDate
+}
+
+// getHistoricalPeriod fetches the period of at most a calendar month, which always fits in a single call, for the current date
+// in the time zone of the location. If neither config nor the location cache provides the zone, the period is first
+// requested for the current date in UTC and requested again if it differs in the zone of the response's location.
+func (service *Service) getHistoricalPeriod(ctx context.Context, config GetHistoricalPeriodConfig, period func(today civil.Date) (civil.Date, civil.Date)) (*HistoricalResponse, *RequestError) {
+	timeZone := config.TimeZone
+	if timeZone == nil {
+		if location, ok := service.locations.get(config.Query); ok {
+			timeZone, _ = location.TimeZone()
+		}
+	}
+
+	if timeZone != nil {
+		startDate, endDate := period(civil.DateOf(time.Now().In(timeZone)))
+		return service.getHistoricalDates(ctx, config, startDate, endDate)
+	}
+
+	startDate, endDate := period(civil.DateOf(time.Now().UTC()))
+
+	historicalResponse, e := service.getHistoricalDates(ctx, config, startDate, endDate)
+	if e != nil {
+		return nil, e
+	}
+
+	timeZone, err := historicalResponse.Location.TimeZone()
+	if err != nil {
+		return historicalResponse, nil
+	}
+
+	if localStartDate, localEndDate := period(civil.DateOf(time.Now().In(timeZone))); localStartDate != startDate || localEndDate != endDate {
+		return service.getHistoricalDates(ctx, config, localStartDate, localEndDate)
+	}
+
+	return historicalResponse, nil
+}
+
+func (service *Service) getHistoricalDates(ctx context.Context, config GetHistoricalPeriodConfig, startDate civil.Date, endDate civil.Date) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalWeatherWithContext(ctx, GetHistoricalWeatherConfig{
+		Query:           config.Query,
+		StartDate:       startDate,
+		EndDate:         &endDate,
+		Hourly:          config.Hourly,
+		Interval:        config.Interval,
+		Units:           config.Units,
+		Language:        config.Language,
+		BaseURLOverride: config.BaseURLOverride,
+	})
+}
@@ -0,0 +1,79 @@
+package weatherstack
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestGetHistoricalLastMonthUsesLocationTimeZone(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/historical_scientific.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body = []byte(strings.Replace(string(body), "Europe/Amsterdam", "Pacific/Kiritimati", 1))
+
+	timeZone, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name   string
+		config ServiceConfig
+	}{
+		{"from response", ServiceConfig{}},
+		{"from location cache", ServiceConfig{LocationCache: &LocationCacheConfig{}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var startDates []string
+			service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				startDates = append(startDates, r.URL.Query().Get("historical_date_start"))
+				w.Write(body)
+			}, test.config)
+
+			if test.config.LocationCache != nil {
+				if _, e := service.GetHistoricalLastMonth(GetHistoricalPeriodConfig{Query: "Amsterdam"}); e != nil {
+					t.Fatal(e.Message())
+				}
+				startDates = nil
+			}
+
+			if _, e := service.GetHistoricalLastMonth(GetHistoricalPeriodConfig{Query: "Amsterdam"}); e != nil {
+				t.Fatal(e.Message())
+			}
+
+			startDate, _ := lastMonth(civil.DateOf(time.Now().In(timeZone)))
+			if len(startDates) == 0 || startDates[len(startDates)-1] != startDate.String() {
+				t.Errorf("requested start dates %v, want %s last", startDates, startDate)
+			}
+			if test.config.LocationCache != nil && len(startDates) != 1 {
+				t.Errorf("got %v requests, want 1", len(startDates))
+			}
+		})
+	}
+}
+
+func TestLastWeek(t *testing.T) {
+	tests := []struct {
+		today     civil.Date
+		wantStart civil.Date
+	}{
+		{civil.Date{Year: 2021, Month: 9, Day: 13}, civil.Date{Year: 2021, Month: 9, Day: 6}},
+		{civil.Date{Year: 2021, Month: 9, Day: 19}, civil.Date{Year: 2021, Month: 9, Day: 6}},
+		{civil.Date{Year: 2021, Month: 1, Day: 3}, civil.Date{Year: 2020, Month: 12, Day: 21}},
+	}
+
+	for _, test := range tests {
+		startDate, endDate := lastWeek(test.today)
+		if startDate != test.wantStart || endDate != test.wantStart.AddDays(6) {
+			t.Errorf("lastWeek(%s) = %s, %s, want %s, %s", test.today, startDate, endDate, test.wantStart, test.wantStart.AddDays(6))
+		}
+	}
+}
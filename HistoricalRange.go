@@ -0,0 +1,70 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetHistoricalWeatherRange requests the historical weather from StartDate through EndDate, which may span more than MaxDaysPerCall days.
+// The range is split in windows of at most MaxDaysPerCall days, which are requested sequentially and merged into one response.
+// An error in any window aborts the whole range.
+func (service *Service) GetHistoricalWeatherRange(config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	return service.GetHistoricalWeatherRangeWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalWeatherRangeWithContext(ctx context.Context, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError) {
+	var merged *HistoricalResponse
+
+	for _, window := range historicalWindows(config) {
+		historicalResponse, e := service.GetHistoricalWeatherWithContext(ctx, window)
+		if e != nil {
+			e.SetMessage(fmt.Sprintf("Window %s - %s failed: %s", window.StartDate, window.EndDate, e.Message()))
+			return nil, e
+		}
+
+		if merged == nil {
+			merged = historicalResponse
+			if merged.Historical == nil {
+				merged.Historical = make(map[string]Weather)
+			}
+			continue
+		}
+
+		merged.Current = historicalResponse.Current
+		for date, weather := range historicalResponse.Historical {
+			merged.Historical[date] = weather
+		}
+	}
+
+	return merged, nil
+}
+
+// historicalWindows splits the date range of config in consecutive, non-overlapping windows of at most MaxDaysPerCall days
+func historicalWindows(config GetHistoricalWeatherConfig) []GetHistoricalWeatherConfig {
+	endDate := config.StartDate
+	if config.EndDate != nil {
+		endDate = *config.EndDate
+	}
+
+	windows := []GetHistoricalWeatherConfig{}
+
+	for startDate := config.StartDate; !startDate.After(endDate); startDate = startDate.AddDays(MaxDaysPerCall) {
+		windowEndDate := startDate.AddDays(MaxDaysPerCall - 1)
+		if windowEndDate.After(endDate) {
+			windowEndDate = endDate
+		}
+
+		window := config
+		window.StartDate = startDate
+		window.EndDate = &windowEndDate
+
+		windows = append(windows, window)
+	}
+
+	// an inverted range is left to GetHistoricalWeather to reject
+	if len(windows) == 0 {
+		windows = append(windows, config)
+	}
+
+	return windows
+}
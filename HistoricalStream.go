@@ -0,0 +1,33 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// StreamHistoricalWeather requests the historical weather from StartDate through EndDate in windows of at most MaxDaysPerCall days,
+// like GetHistoricalWeatherRange, but invokes callback for each day in chronological order instead of merging the windows.
+// Only one window is held in memory at a time. An error returned by callback aborts the stream and is returned.
+func (service *Service) StreamHistoricalWeather(config GetHistoricalWeatherConfig, callback func(day HistoricalWeather, location Location) error) *RequestError {
+	return service.StreamHistoricalWeatherWithContext(context.Background(), config, callback)
+}
+
+func (service *Service) StreamHistoricalWeatherWithContext(ctx context.Context, config GetHistoricalWeatherConfig, callback func(day HistoricalWeather, location Location) error) *RequestError {
+	for _, window := range historicalWindows(config) {
+		historicalResponse, e := service.GetHistoricalWeatherWithContext(ctx, window)
+		if e != nil {
+			e.SetMessage(fmt.Sprintf("Window %s - %s failed: %s", window.StartDate, window.EndDate, e.Message()))
+			return e
+		}
+
+		for _, day := range historicalResponse.Days() {
+			if err := callback(day, historicalResponse.Location); err != nil {
+				return newRequestError(errortools.ErrorMessage(err), err)
+			}
+		}
+	}
+
+	return nil
+}
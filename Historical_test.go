@@ -0,0 +1,59 @@
+package weatherstack
+
+import (
+	"io/ioutil"
+	"math"
+	"testing"
+)
+
+func TestDecodeScientificUnits(t *testing.T) {
+	rawResponse, err := ioutil.ReadFile("testdata/historical_scientific.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	historicalResponse := HistoricalResponse{}
+	e := (&Service{}).decodeResponse(rawResponse, &historicalResponse, nil)
+	if e != nil {
+		t.Fatalf("decodeResponse() error = %s", e.Message())
+	}
+
+	current := historicalResponse.Current
+	day := historicalResponse.Historical["2021-09-09"]
+	if len(day.Hourly) != 1 {
+		t.Fatalf("got %v hourly records, want 1", len(day.Hourly))
+	}
+	hourly := day.Hourly[0]
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"current.temperature", current.Temperature.Value(), 292.15},
+		{"current.feelslike", current.FeelsLike.Value(), 291.65},
+		{"current.wind_speed", current.WindSpeed.Value(), 4.7},
+		{"current.pressure", current.Pressure.Value(), 1017.5},
+		{"current.precip", current.Precip.Value(), 0.1},
+		{"current.temperature in °C", current.TemperatureValue().Celsius(), 19},
+		{"mintemp", day.MinTemp.Value(), 287.65},
+		{"avgtemp", day.AvgTemp.Value(), 291.2},
+		{"hourly.temperature", hourly.Temperature.Value(), 288.35},
+		{"hourly.wind_speed", hourly.WindSpeed.Value(), 3.3},
+		{"hourly.pressure", hourly.Pressure.Value(), 1018.25},
+		{"hourly.feelslike", hourly.FeelsLike.Value(), 287.85},
+		{"hourly.dewpoint", hourly.Dewpoint.Value(), 286.15},
+		{"hourly.windgust", hourly.Windgust.Value(), 6.1},
+		{"hourly.temperature in °C", hourly.TemperatureValue().Celsius(), 15.2},
+	}
+
+	for _, test := range tests {
+		if math.Abs(test.got-test.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", test.name, test.got, test.want)
+		}
+	}
+
+	if current.units != UnitsScientific || hourly.units != UnitsScientific {
+		t.Errorf("units = %q and %q, want %q", current.units, hourly.units, UnitsScientific)
+	}
+}
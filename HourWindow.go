@@ -0,0 +1,76 @@
+package weatherstack
+
+import "cloud.google.com/go/civil"
+
+// HourWindow is a range of local times of day, From and To included.
+// A window with From after To wraps around midnight, e.g. 22:00 to 06:00.
+type HourWindow struct {
+	From civil.Time
+	To   civil.Time
+}
+
+// Contains reports whether t is within the window
+func (window HourWindow) Contains(t civil.Time) bool {
+	from, to, at := secondOfDay(window.From), secondOfDay(window.To), secondOfDay(t)
+
+	if to < from {
+		return at >= from || at <= to
+	}
+
+	return at >= from && at <= to
+}
+
+func secondOfDay(t civil.Time) int {
+	return (t.Hour*60+t.Minute)*60 + t.Second
+}
+
+// HoursIn returns the hourly records with a Time within window, Time being in the location's local time.
+// Records with an invalid Time are left out.
+func (w Weather) HoursIn(window HourWindow) []HourlyWeather {
+	hours := []HourlyWeather{}
+
+	for _, hourly := range w.Hourly {
+		timeOfDay, err := hourly.TimeOfDay()
+		if err != nil {
+			continue
+		}
+
+		if window.Contains(civil.TimeOf(timeOfDay)) {
+			hours = append(hours, hourly)
+		}
+	}
+
+	return hours
+}
+
+// FilterHours returns a copy of the response with the hourly records of each day trimmed to window, see Weather.HoursIn.
+// The response itself is not modified.
+func (r *HistoricalResponse) FilterHours(window HourWindow) *HistoricalResponse {
+	filtered := *r
+	filtered.Historical = filterHours(r.Historical, window)
+
+	return &filtered
+}
+
+// FilterHours returns a copy of the response with the hourly records of each day trimmed to window, see Weather.HoursIn.
+// The response itself is not modified.
+func (r *ForecastResponse) FilterHours(window HourWindow) *ForecastResponse {
+	filtered := *r
+	filtered.Forecast = filterHours(r.Forecast, window)
+
+	return &filtered
+}
+
+func filterHours(days map[string]Weather, window HourWindow) map[string]Weather {
+	if days == nil {
+		return nil
+	}
+
+	filtered := make(map[string]Weather, len(days))
+	for date, day := range days {
+		day.Hourly = day.HoursIn(window)
+		filtered[date] = day
+	}
+
+	return filtered
+}
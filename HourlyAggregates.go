@@ -0,0 +1,81 @@
+package weatherstack
+
+// intervalHours derives the number of hours each hourly record covers from the spacing of the Time values
+// ("0", "300", "600", ...), a single record covers the whole day
+func (w Weather) intervalHours() float64 {
+	if len(w.Hourly) < 2 {
+		return 24
+	}
+
+	spacing := int64(w.Hourly[1].Time) - int64(w.Hourly[0].Time)
+	if spacing <= 0 {
+		return 24 / float64(len(w.Hourly))
+	}
+
+	return float64(spacing) / 100
+}
+
+// PrecipFromHourly recomputes the daily precipitation from the hourly records.
+// Each record's Precip is assumed to be the hourly amount for the interval it represents,
+// so it is multiplied by the interval length in hours, derived from the spacing of the records' Time values.
+// ok is false when there are no hourly records.
+func (w Weather) PrecipFromHourly() (precip float64, ok bool) {
+	if len(w.Hourly) == 0 {
+		return 0, false
+	}
+
+	intervalHours := w.intervalHours()
+
+	for _, hourly := range w.Hourly {
+		precip += hourly.Precip.Value() * intervalHours
+	}
+
+	return precip, true
+}
+
+// DayNightAggregates returns the day and night aggregates of a day requested with IntervalDayNight.
+// The record whose Time is closest to noon is taken as the day aggregate, the other one as the night aggregate.
+// ok is false unless the day holds exactly two hourly records.
+func (w Weather) DayNightAggregates() (day HourlyWeather, night HourlyWeather, ok bool) {
+	if len(w.Hourly) != 2 {
+		return HourlyWeather{}, HourlyWeather{}, false
+	}
+
+	distanceToNoon := func(h HourlyWeather) int64 {
+		distance := int64(h.Time) - 1200
+		if distance < 0 {
+			return -distance
+		}
+		return distance
+	}
+
+	day, night = w.Hourly[0], w.Hourly[1]
+	if distanceToNoon(night) < distanceToNoon(day) {
+		day, night = night, day
+	}
+
+	return day, night, true
+}
+
+// ThunderstormHours returns the hourly records with a ChanceOfThunder above threshold (%), records without ChanceOfThunder excluded
+func (w Weather) ThunderstormHours(threshold int) []HourlyWeather {
+	thunderstormHours := []HourlyWeather{}
+
+	for _, hourly := range w.Hourly {
+		if hourly.ChanceOfThunder.Valid && hourly.ChanceOfThunder.Value() > int64(threshold) {
+			thunderstormHours = append(thunderstormHours, hourly)
+		}
+	}
+
+	return thunderstormHours
+}
+
+// HasLikelyThunderstorm reports whether any hourly record has a ChanceOfThunder above threshold (%)
+func (w Weather) HasLikelyThunderstorm(threshold int) bool {
+	return len(w.ThunderstormHours(threshold)) > 0
+}
+
+// HourlyComplete reports whether the day holds the number of hourly records the interval implies
+func (w Weather) HourlyComplete(interval Interval) bool {
+	return len(w.Hourly) >= interval.ExpectedHourlyCount()
+}
@@ -0,0 +1,47 @@
+package weatherstack
+
+import (
+	"testing"
+
+	go_types "github.com/leapforce-libraries/go_types"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestThunderstormHours(t *testing.T) {
+	chance := func(time int64, chanceOfThunder int64) HourlyWeather {
+		return HourlyWeather{Time: go_types.Int64String(time), ChanceOfThunder: w_types.NullInt64OrString{Int64: chanceOfThunder, Valid: true}}
+	}
+
+	day := Weather{Hourly: []HourlyWeather{chance(0, 20), chance(300, 50), chance(600, 51), {Time: 900}}}
+
+	tests := []struct {
+		name      string
+		weather   Weather
+		threshold int
+		wantTimes []int64
+	}{
+		{"above threshold", day, 20, []int64{300, 600}},
+		{"equal to threshold excluded", day, 50, []int64{600}},
+		{"none above threshold", day, 51, []int64{}},
+		{"absent chance excluded", day, -1, []int64{0, 300, 600}},
+		{"empty day", Weather{}, 0, []int64{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hours := test.weather.ThunderstormHours(test.threshold)
+			if hours == nil || len(hours) != len(test.wantTimes) {
+				t.Fatalf("ThunderstormHours(%v) = %v, want times %v", test.threshold, hours, test.wantTimes)
+			}
+			for i, hour := range hours {
+				if int64(hour.Time) != test.wantTimes[i] {
+					t.Errorf("ThunderstormHours(%v)[%v].Time = %v, want %v", test.threshold, i, int64(hour.Time), test.wantTimes[i])
+				}
+			}
+
+			if got := test.weather.HasLikelyThunderstorm(test.threshold); got != (len(test.wantTimes) > 0) {
+				t.Errorf("HasLikelyThunderstorm(%v) = %v, want %v", test.threshold, got, len(test.wantTimes) > 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package weatherstack
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// TimeOfDay returns the time of day of the hourly record, on date 0000-01-01 UTC.
+// Time is an hhmm code ("0", "300", ..., "2100").
+func (h HourlyWeather) TimeOfDay() (time.Time, error) {
+	code := int(h.Time)
+
+	if code < 0 || code/100 > 23 || code%100 > 59 {
+		return time.Time{}, fmt.Errorf("invalid hourly time: %v", code)
+	}
+
+	return time.Date(0, time.January, 1, code/100, code%100, 0, 0, time.UTC), nil
+}
+
+// TimeOn returns the instant of the hourly record on date in loc (defaults to UTC)
+func (h HourlyWeather) TimeOn(date civil.Date, loc *time.Location) (time.Time, error) {
+	timeOfDay, err := h.TimeOfDay()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return time.Date(date.Year, date.Month, date.Day, timeOfDay.Hour(), timeOfDay.Minute(), 0, 0, loc), nil
+}
+
+// HourlyByTime returns the hourly records of the day keyed by their instant in loc.
+// Hours that do not exist due to a DST transition are normalized by time.Date (e.g. 02:00 becomes 03:00),
+// if two records map to the same instant the last one wins. Records with an invalid Time are skipped.
+func (w Weather) HourlyByTime(date civil.Date, loc *time.Location) map[time.Time]HourlyWeather {
+	hourlyByTime := make(map[time.Time]HourlyWeather, len(w.Hourly))
+
+	for _, hourly := range w.Hourly {
+		t, err := hourly.TimeOn(date, loc)
+		if err != nil {
+			continue
+		}
+		hourlyByTime[t] = hourly
+	}
+
+	return hourlyByTime
+}
+
+// HourlyRecord is an hourly record along with its instant and location
+type HourlyRecord struct {
+	Time     time.Time
+	Location string
+	HourlyWeather
+}
+
+// Flatten returns the hourly records of all days in chronological order, their instant resolved in the location's time zone.
+// If the time zone cannot be determined an error is returned, records with an invalid Time are skipped.
+func (r *HistoricalResponse) Flatten() ([]HourlyRecord, error) {
+	loc, err := r.Location.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []HourlyRecord{}
+
+	for _, day := range r.Days() {
+		date := day.CivilDate()
+
+		for _, hourly := range day.Hourly {
+			t, err := hourly.TimeOn(date, loc)
+			if err != nil {
+				continue
+			}
+
+			records = append(records, HourlyRecord{
+				Time:          t,
+				Location:      r.Location.Name,
+				HourlyWeather: hourly,
+			})
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	return records, nil
+}
@@ -0,0 +1,30 @@
+package weatherstack
+
+import "math"
+
+// Magnus formula constants (Alduchov & Eskridge, 1996), valid for -40°C to 50°C
+const (
+	magnusB float64 = 17.625
+	magnusC float64 = 243.04
+)
+
+// RelativeHumidity computes the relative humidity (%) from temperature and dewpoint in degrees Celsius
+// using the Magnus formula:
+//
+//	RH = 100 * exp(b*Td/(c+Td)) / exp(b*T/(c+T))
+//
+// with b = 17.625 and c = 243.04°C.
+func RelativeHumidity(tempC, dewpointC float64) float64 {
+	rh := 100 * math.Exp(magnusB*dewpointC/(magnusC+dewpointC)) / math.Exp(magnusB*tempC/(magnusC+tempC))
+
+	return math.Max(0, math.Min(100, rh))
+}
+
+// ComputedHumidity computes the relative humidity (%) from Temperature and Dewpoint, NaN if Dewpoint is absent
+func (h HourlyWeather) ComputedHumidity() float64 {
+	if !h.Dewpoint.Valid {
+		return math.NaN()
+	}
+
+	return RelativeHumidity(h.TemperatureValue().Celsius(), h.DewpointValue().Celsius())
+}
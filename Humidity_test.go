@@ -0,0 +1,26 @@
+package weatherstack
+
+import (
+	"math"
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestComputedHumidityUsesRequestUnits(t *testing.T) {
+	want := RelativeHumidity(20, 10)
+
+	for _, hourly := range []HourlyWeather{
+		{Temperature: 20, Dewpoint: w_types.NullFloat64OrString{Float64: 10, Valid: true}, units: UnitsMetric},
+		{Temperature: 293.15, Dewpoint: w_types.NullFloat64OrString{Float64: 283.15, Valid: true}, units: UnitsScientific},
+		{Temperature: 68, Dewpoint: w_types.NullFloat64OrString{Float64: 50, Valid: true}, units: UnitsFahrenheit},
+	} {
+		if got := hourly.ComputedHumidity(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("ComputedHumidity() in units %q = %v, want %v", hourly.units, got, want)
+		}
+	}
+
+	if got := (HourlyWeather{Temperature: 20, units: UnitsMetric}).ComputedHumidity(); !math.IsNaN(got) {
+		t.Errorf("ComputedHumidity() without dewpoint = %v, want NaN", got)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build go1.23
+
+package weatherstack
+
+import (
+	"iter"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// All returns an iterator over the days in chronological order
+func (r *HistoricalResponse) All() iter.Seq2[civil.Date, HistoricalWeather] {
+	return allDays(r.Historical)
+}
+
+// Hours returns an iterator over the hourly records of all days in chronological order, keyed by their instant
+// in the location's time zone (see Location.Location), UTC if it cannot be determined. Records with an invalid Time are skipped.
+func (r *HistoricalResponse) Hours() iter.Seq2[time.Time, HourlyWeather] {
+	return allHours(r.Historical, r.Location)
+}
+
+// All returns an iterator over the forecast days in chronological order
+func (r *ForecastResponse) All() iter.Seq2[civil.Date, ForecastWeather] {
+	return allDays(r.Forecast)
+}
+
+// Hours returns an iterator over the hourly records of the forecast days, see HistoricalResponse.Hours
+func (r *ForecastResponse) Hours() iter.Seq2[time.Time, HourlyWeather] {
+	return allHours(r.Forecast, r.Location)
+}
+
+func allDays(days map[string]Weather) iter.Seq2[civil.Date, Weather] {
+	return func(yield func(civil.Date, Weather) bool) {
+		for _, date := range sortedDates(days) {
+			if !yield(date, days[date.String()]) {
+				return
+			}
+		}
+	}
+}
+
+func allHours(days map[string]Weather, location Location) iter.Seq2[time.Time, HourlyWeather] {
+	return func(yield func(time.Time, HourlyWeather) bool) {
+		loc, err := location.Location()
+		if err != nil {
+			loc = time.UTC
+		}
+
+		for _, date := range sortedDates(days) {
+			hourly := append([]HourlyWeather(nil), days[date.String()].Hourly...)
+			sort.SliceStable(hourly, func(i, j int) bool {
+				return hourly[i].Time < hourly[j].Time
+			})
+
+			for _, h := range hourly {
+				t, err := h.TimeOn(date, loc)
+				if err != nil {
+					continue
+				}
+
+				if !yield(t, h) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package weatherstack
 
 import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"cloud.google.com/go/civil"
 	go_types "github.com/leapforce-libraries/go_types"
 	w_types "github.com/leapforce-libraries/go_weatherstack/types"
 )
@@ -16,3 +23,127 @@ type Location struct {
 	LocaltimeEpoch int64                  `json:"localtime_epoch"`
 	UTCOffset      go_types.Float64String `json:"utc_offset"`
 }
+
+// IsDST reports whether daylight saving time is in effect at noon on date d in the location's time zone.
+// DST is detected by comparing the UTC offset to the standard offset, being the smaller of the January and July offsets.
+// If the time zone cannot be loaded false is returned along with the error.
+func (l Location) IsDST(d civil.Date) (bool, error) {
+	timeZone, err := l.TimeZone()
+	if err != nil {
+		return false, err
+	}
+
+	offset := func(month time.Month, day int) int {
+		_, offset := time.Date(d.Year, month, day, 12, 0, 0, 0, timeZone).Zone()
+		return offset
+	}
+
+	standardOffset := offset(time.January, 1)
+	if julyOffset := offset(time.July, 1); julyOffset < standardOffset {
+		standardOffset = julyOffset
+	}
+
+	return offset(d.Month, d.Day) > standardOffset, nil
+}
+
+// NormalizedName returns Name trimmed, without periods, with whitespace collapsed and title-cased per word
+// (including words after a hyphen or apostrophe), e.g. " new  york" -> "New York", "St. louis" -> "St Louis"
+func (l Location) NormalizedName() string {
+	name := strings.Join(strings.Fields(strings.ReplaceAll(l.Name, ".", " ")), " ")
+
+	normalized := []rune{}
+	startOfWord := true
+
+	for _, r := range name {
+		if startOfWord {
+			normalized = append(normalized, unicode.ToUpper(r))
+		} else {
+			normalized = append(normalized, unicode.ToLower(r))
+		}
+
+		startOfWord = r == ' ' || r == '-' || r == '\''
+	}
+
+	return string(normalized)
+}
+
+// Coordinates returns Lat and Lon, which Weatherstack returns as strings.
+// An error is returned when the location is unresolved (empty coordinates) or the coordinates are out of range.
+func (l Location) Coordinates() (lat float64, lon float64, err error) {
+	lat, lon = float64(l.Lat), float64(l.Lon)
+
+	if lat == 0 && lon == 0 {
+		return 0, 0, fmt.Errorf("location %s has no coordinates", l.Name)
+	}
+
+	if math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return 0, 0, fmt.Errorf("location %s has invalid coordinates %v,%v", l.Name, lat, lon)
+	}
+
+	return lat, lon, nil
+}
+
+// UTCOffsetDuration returns UTCOffset, which Weatherstack returns in (fractional) hours, e.g. "5.75" -> 5h45m
+func (l Location) UTCOffsetDuration() (time.Duration, error) {
+	offset := float64(l.UTCOffset)
+
+	if math.Abs(offset) > 14 {
+		return 0, fmt.Errorf("location %s has invalid utc offset %v", l.Name, offset)
+	}
+
+	return time.Duration(math.Round(offset*60)) * time.Minute, nil
+}
+
+// Location returns the time zone of TimezoneID, falling back to a fixed zone of UTCOffset
+// if TimezoneID is empty or cannot be loaded
+func (l Location) Location() (*time.Location, error) {
+	if l.TimezoneID != "" {
+		timeZone, err := time.LoadLocation(l.TimezoneID)
+		if err == nil {
+			return timeZone, nil
+		}
+	}
+
+	offset, err := l.UTCOffsetDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+	}
+	minutes := int(math.Abs(offset.Minutes()))
+
+	return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", sign, minutes/60, minutes%60), int(offset.Seconds())), nil
+}
+
+// TimeZone returns the IANA time zone of TimezoneID, being DST aware contrary to the fixed zone Location may fall back to
+func (l Location) TimeZone() (*time.Location, error) {
+	if l.TimezoneID == "" {
+		return nil, fmt.Errorf("location %s has no time zone", l.Name)
+	}
+
+	return time.LoadLocation(l.TimezoneID)
+}
+
+// LocalTime returns the local time of the location at the moment of the request, in the zone returned by Location.
+// Weatherstack encodes the local wall clock time as if it were UTC in both Localtime and LocaltimeEpoch,
+// so the wall clock time is reinterpreted in the location's zone.
+func (l Location) LocalTime() (time.Time, error) {
+	loc, err := l.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	localtime := l.Localtime.Value()
+	if l.LocaltimeEpoch != 0 {
+		localtime = time.Unix(l.LocaltimeEpoch, 0).UTC()
+	}
+
+	if localtime.IsZero() {
+		return time.Time{}, fmt.Errorf("location %s has no local time", l.Name)
+	}
+
+	return time.Date(localtime.Year(), localtime.Month(), localtime.Day(), localtime.Hour(), localtime.Minute(), 0, 0, loc), nil
+}
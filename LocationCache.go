@@ -0,0 +1,121 @@
+package weatherstack
+
+import (
+	"strings"
+	"sync"
+)
+
+// LocationCacheConfig configures remembering the Location each query resolved to
+type LocationCacheConfig struct {
+	RewriteQueries bool // request known queries by the coordinates they resolved to, so the same location is used across calls
+}
+
+// locationCache maps normalized queries to the location Weatherstack resolved them to, a nil *locationCache remembers nothing
+type locationCache struct {
+	rewriteQueries bool
+	mutex          sync.RWMutex
+	locations      map[string]Location
+}
+
+func newLocationCache(config *LocationCacheConfig) *locationCache {
+	if config == nil {
+		return nil
+	}
+
+	return &locationCache{
+		rewriteQueries: config.RewriteQueries,
+		locations:      make(map[string]Location),
+	}
+}
+
+// locationCacheKey returns the key of query, ok is false for queries that do not resolve to a fixed location
+func locationCacheKey(query string) (string, bool) {
+	key := strings.ToLower(strings.TrimSpace(query))
+
+	if key == "" || strings.HasPrefix(key, "fetch:") || strings.Contains(key, ";") {
+		return "", false
+	}
+
+	return key, true
+}
+
+// remember stores the location query resolved to, if it has valid coordinates.
+// The first resolution is kept, so a query rewritten to coordinates keeps its original location.
+func (cache *locationCache) remember(query string, location Location) {
+	if cache == nil {
+		return
+	}
+
+	key, ok := locationCacheKey(query)
+	if !ok {
+		return
+	}
+
+	if _, _, err := location.Coordinates(); err != nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if _, ok := cache.locations[key]; !ok {
+		cache.locations[key] = location
+	}
+}
+
+func (cache *locationCache) get(query string) (Location, bool) {
+	if cache == nil {
+		return Location{}, false
+	}
+
+	key, ok := locationCacheKey(query)
+	if !ok {
+		return Location{}, false
+	}
+
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	location, ok := cache.locations[key]
+
+	return location, ok
+}
+
+// rewrite replaces a known query by the coordinates it resolved to, if RewriteQueries is set
+func (cache *locationCache) rewrite(query string, coordinates *Coordinates) (string, *Coordinates) {
+	if cache == nil || !cache.rewriteQueries || coordinates != nil {
+		return query, coordinates
+	}
+
+	location, ok := cache.get(query)
+	if !ok {
+		return query, coordinates
+	}
+
+	lat, lon, _ := location.Coordinates()
+
+	return "", &Coordinates{Lat: lat, Lon: lon}
+}
+
+// ResolvedLocation returns the location query resolved to in an earlier response, see ServiceConfig.LocationCache
+func (service *Service) ResolvedLocation(query string) (Location, bool) {
+	return service.locations.get(query)
+}
+
+// ResolvedLocations returns a copy of all remembered locations, keyed by normalized (trimmed, lower case) query
+func (service *Service) ResolvedLocations() map[string]Location {
+	locations := make(map[string]Location)
+
+	if service.locations == nil {
+		return locations
+	}
+
+	service.locations.mutex.RLock()
+	defer service.locations.mutex.RUnlock()
+
+	for key, location := range service.locations.locations {
+		locations[key] = location
+	}
+
+	return locations
+}
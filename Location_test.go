@@ -0,0 +1,29 @@
+package weatherstack
+
+import "testing"
+
+func TestNormalizedName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"New york", "New York"},
+		{" NEW  YORK ", "New York"},
+		{"\tnew\nyork", "New York"},
+		{"St. louis", "St Louis"},
+		{"Washington, D.C.", "Washington, D C"},
+		{"wilkes-BARRE", "Wilkes-Barre"},
+		{"o'fallon", "O'Fallon"},
+		{"são paulo", "São Paulo"},
+		{"", ""},
+		{" . ", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := (Location{Name: test.name}).NormalizedName(); got != test.want {
+				t.Errorf("NormalizedName() of %q = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
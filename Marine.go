@@ -0,0 +1,149 @@
+package weatherstack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	go_http "github.com/leapforce-libraries/go_http"
+	go_types "github.com/leapforce-libraries/go_types"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+type MarineResponse struct {
+	Request     Request                  `json:"request"`
+	Location    Location                 `json:"location"`
+	Marine      map[string]MarineWeather `json:"marine"`
+	RawResponse []byte                   `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true
+	Meta        *ResponseMeta            `json:"-"` // only set if ServiceConfig.IncludeRawResponse is true and the response was not cached
+}
+
+type MarineWeather struct {
+	Date      w_types.DateString      `json:"date"`
+	DateEpoch int64                   `json:"date_epoch"`
+	Astro     Astro                   `json:"astro"`
+	MinTemp   w_types.Float64OrString `json:"mintemp"`
+	MaxTemp   w_types.Float64OrString `json:"maxtemp"`
+	Tides     []Tide                  `json:"tides"`
+	Hourly    []MarineHourlyWeather   `json:"hourly"`
+}
+
+type Tide struct {
+	TideTime   w_types.TimeStruct     `json:"tide_time"`
+	TideHeight go_types.Float64String `json:"tide_height_mt"`
+	TideType   string                 `json:"tide_type"`
+}
+
+type MarineHourlyWeather struct {
+	Time                  go_types.Int64String    `json:"time"`
+	Temperature           w_types.Float64OrString `json:"temperature"`
+	WindSpeed             w_types.Float64OrString `json:"wind_speed"`
+	WindDegree            w_types.Int64OrString   `json:"wind_degree"`
+	WindDir               string                  `json:"wind_dir"`
+	WeatherCode           WeatherCode             `json:"weather_code"`
+	WeatherIcons          []string                `json:"weather_icons"`
+	WeatherDescriptions   []string                `json:"weather_descriptions"`
+	Precip                w_types.Float64OrString `json:"precip"`
+	Humidity              w_types.Int64OrString   `json:"humidity"`
+	Visibility            w_types.Float64OrString `json:"visibility"`
+	Pressure              w_types.Float64OrString `json:"pressure"`
+	Cloudcover            w_types.Int64OrString   `json:"cloudcover"`
+	SigHeight             float64                 `json:"sig_height_m"`
+	SwellHeight           float64                 `json:"swell_height_m"`
+	SwellDirection        w_types.Int64OrString   `json:"swell_dir"`
+	SwellDirection16Point string                  `json:"swell_dir_16_point"`
+	SwellPeriod           float64                 `json:"swell_period_secs"`
+	WaterTemperature      w_types.Float64OrString `json:"water_temp"`
+	UVIndex               w_types.Int64OrString   `json:"uv_index"`
+}
+
+type GetMarineWeatherConfig struct {
+	Query           string
+	Coordinates     *Coordinates // alternative to Query
+	Hourly          *Hourly
+	Interval        *Interval
+	Units           *Units
+	Tide            *bool
+	BaseURLOverride *string
+	ExtraParams     url.Values     // merged into the query string, overriding parameters with the same name
+	Timeout         *time.Duration // of the call including retries, in addition to the deadline of the context
+}
+
+// Validate checks the config locally, returning all violations at once
+func (config GetMarineWeatherConfig) Validate() *errortools.Error {
+	var v violations
+
+	v.add(validateQueryOrCoordinates(config.Query, config.Coordinates))
+	v.add(validateTimeout(config.Timeout))
+	v.add(validateParameters(config.Units, config.Interval, nil))
+	v.add(validateHourlyInterval(config.Hourly, config.Interval))
+
+	return v.error()
+}
+
+func (service *Service) GetMarineWeather(config GetMarineWeatherConfig) (*MarineResponse, *RequestError) {
+	return service.GetMarineWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetMarineWeatherWithContext(ctx context.Context, config GetMarineWeatherConfig) (*MarineResponse, *RequestError) {
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	_url, e := service.marineURL(config)
+	if e != nil {
+		return nil, newRequestError(e, nil)
+	}
+
+	marineResponse := MarineResponse{}
+
+	requestConfig := go_http.RequestConfig{
+		URL:           _url,
+		ResponseModel: &marineResponse,
+	}
+
+	_, _, requestError := service.get(ctx, &requestConfig)
+	if requestError != nil {
+		return nil, requestError
+	}
+
+	e = marineResponse.Validate()
+	if e != nil {
+		return nil, newRequestError(e, nil)
+	}
+
+	service.locations.remember(config.Query, marineResponse.Location)
+
+	return &marineResponse, nil
+}
+
+func (service *Service) marineURL(config GetMarineWeatherConfig) (string, *errortools.Error) {
+	config.Query, config.Coordinates = service.locations.rewrite(config.Query, config.Coordinates)
+	config.Units = service.unitsOrDefault(config.Units)
+	config.Hourly = service.hourlyOrImplied(config.Hourly, config.Interval)
+
+	e := config.Validate()
+	if e != nil {
+		return "", e
+	}
+
+	query, e := resolveQuery(config.Query, config.Coordinates)
+	if e != nil {
+		return "", e
+	}
+
+	values := baseValues(query, config.Units, config.Hourly, config.Interval, nil)
+
+	if config.Tide != nil {
+		if *config.Tide {
+			values.Add("tide", "yes")
+		} else {
+			values.Add("tide", "no")
+		}
+	}
+
+	mergeExtraParams(values, config.ExtraParams)
+
+	return service.urlWithOverride(config.BaseURLOverride, fmt.Sprintf("marine?%s", values.Encode()))
+}
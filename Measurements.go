@@ -0,0 +1,187 @@
+package weatherstack
+
+// Temperature is a temperature along with the units it was returned in
+type Temperature struct {
+	Value float64
+	Units Units
+}
+
+func (t Temperature) Celsius() float64 {
+	return temperatureToCelsius(t.Value, t.Units)
+}
+
+func (t Temperature) Fahrenheit() float64 {
+	return temperatureFromCelsius(t.Celsius(), UnitsFahrenheit)
+}
+
+func (t Temperature) Kelvin() float64 {
+	return temperatureFromCelsius(t.Celsius(), UnitsScientific)
+}
+
+// Speed is a wind speed along with the units it was returned in
+type Speed struct {
+	Value float64
+	Units Units
+}
+
+func (s Speed) KmH() float64 {
+	return speedToKmH(s.Value, s.Units)
+}
+
+func (s Speed) Mph() float64 {
+	return speedFromKmH(s.KmH(), UnitsFahrenheit)
+}
+
+func (s Speed) MetersPerSecond() float64 {
+	return s.KmH() / 3.6
+}
+
+// Pressure is an air pressure, which Weatherstack returns in millibar regardless of the units
+type Pressure struct {
+	Value float64
+	Units Units
+}
+
+func (p Pressure) Millibars() float64 {
+	return p.Value
+}
+
+func (p Pressure) InchesOfMercury() float64 {
+	return p.Value / 33.8639
+}
+
+// Precipitation is an amount of precipitation along with the units it was returned in (millimeters, or inches for UnitsFahrenheit)
+type Precipitation struct {
+	Value float64
+	Units Units
+}
+
+func (p Precipitation) Millimeters() float64 {
+	if p.Units == UnitsFahrenheit {
+		return p.Value * 25.4
+	}
+
+	return p.Value
+}
+
+func (p Precipitation) Inches() float64 {
+	return p.Millimeters() / 25.4
+}
+
+// Distance is a visibility along with the units it was returned in (kilometers, or miles for UnitsFahrenheit)
+type Distance struct {
+	Value float64
+	Units Units
+}
+
+func (d Distance) Kilometers() float64 {
+	return distanceToKm(d.Value, d.Units)
+}
+
+func (d Distance) Miles() float64 {
+	return d.Kilometers() / 1.609344
+}
+
+// The measurement accessors use the units of the request, being set when the response is decoded.
+// Records not decoded by the service are taken to be in UnitsMetric.
+
+func (c CurrentWeather) TemperatureValue() Temperature {
+	return Temperature{c.Temperature.Value(), c.units}
+}
+
+func (c CurrentWeather) FeelsLikeValue() Temperature {
+	return Temperature{c.FeelsLike.Value(), c.units}
+}
+
+func (c CurrentWeather) WindSpeedValue() Speed {
+	return Speed{c.WindSpeed.Value(), c.units}
+}
+
+func (c CurrentWeather) PressureValue() Pressure {
+	return Pressure{c.Pressure.Value(), c.units}
+}
+
+func (c CurrentWeather) PrecipValue() Precipitation {
+	return Precipitation{c.Precip.Value(), c.units}
+}
+
+func (c CurrentWeather) VisibilityValue() Distance {
+	return Distance{c.Visibility.Value(), c.units}
+}
+
+func (w Weather) MinTempValue() Temperature {
+	return Temperature{w.MinTemp.Value(), w.units}
+}
+
+func (w Weather) MaxTempValue() Temperature {
+	return Temperature{w.MaxTemp.Value(), w.units}
+}
+
+func (w Weather) AvgTempValue() Temperature {
+	return Temperature{w.AvgTemp.Value(), w.units}
+}
+
+func (h HourlyWeather) TemperatureValue() Temperature {
+	return Temperature{h.Temperature.Value(), h.units}
+}
+
+func (h HourlyWeather) FeelsLikeValue() Temperature {
+	return Temperature{h.FeelsLike.Value(), h.units}
+}
+
+func (h HourlyWeather) DewpointValue() Temperature {
+	return Temperature{h.Dewpoint.Value(), h.units}
+}
+
+func (h HourlyWeather) WindSpeedValue() Speed {
+	return Speed{h.WindSpeed.Value(), h.units}
+}
+
+func (h HourlyWeather) WindgustValue() Speed {
+	return Speed{h.Windgust.Value(), h.units}
+}
+
+func (h HourlyWeather) PressureValue() Pressure {
+	return Pressure{h.Pressure.Value(), h.units}
+}
+
+func (h HourlyWeather) PrecipValue() Precipitation {
+	return Precipitation{h.Precip.Value(), h.units}
+}
+
+func (h HourlyWeather) VisibilityValue() Distance {
+	return Distance{h.Visibility.Value(), h.units}
+}
+
+// unitsSetter is implemented by response models whose records carry the units of the request
+type unitsSetter interface {
+	setUnits()
+}
+
+func (r *CurrentResponse) setUnits() {
+	r.Current.units = Units(r.Request.Unit)
+}
+
+func (r *HistoricalResponse) setUnits() {
+	units := Units(r.Request.Unit)
+
+	r.Current.units = units
+	setWeatherUnits(r.Historical, units)
+}
+
+func (r *ForecastResponse) setUnits() {
+	units := Units(r.Request.Unit)
+
+	r.Current.units = units
+	setWeatherUnits(r.Forecast, units)
+}
+
+func setWeatherUnits(days map[string]Weather, units Units) {
+	for date, day := range days {
+		day.units = units
+		for i := range day.Hourly {
+			day.Hourly[i].units = units
+		}
+		days[date] = day
+	}
+}
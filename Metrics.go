@@ -0,0 +1,43 @@
+package weatherstack
+
+import (
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// MetricsHook is an optional hook to collect metrics, e.g. to bridge to Prometheus.
+// Implementations must be safe for concurrent use.
+type MetricsHook interface {
+	ObserveRequest(metrics RequestMetrics)
+	SetQuotaRemaining(remaining int64) // only invoked if ServiceConfig.MaxRequests is set
+}
+
+// RequestMetrics describes a completed request including its retries
+type RequestMetrics struct {
+	Endpoint   string
+	Duration   time.Duration // 0 for cached responses
+	Attempts   int           // 0 for cached and refused (e.g. by ServiceConfig.MaxRequests) requests
+	Cached     bool
+	Success    bool
+	StatusCode int       // of the last attempt, 0 if no response was received
+	ErrorCode  ErrorCode // Weatherstack error code of the last attempt, 0 if none
+}
+
+func (service *Service) observeRequest(_url *url.URL, metrics RequestMetrics) {
+	if service.metrics == nil {
+		return
+	}
+
+	metrics.Endpoint = strings.TrimPrefix(_url.Path, "/")
+	service.metrics.ObserveRequest(metrics)
+
+	if service.maxRequests != nil {
+		remaining := *service.maxRequests - atomic.LoadInt64(&service.requestsUsed)
+		if remaining < 0 {
+			remaining = 0
+		}
+		service.metrics.SetQuotaRemaining(remaining)
+	}
+}
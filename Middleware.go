@@ -0,0 +1,23 @@
+package weatherstack
+
+import "net/http"
+
+type RoundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f RoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps the sending of each request attempt, e.g. for logging, header injection or auditing.
+// Note that the request URL contains the access key.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// applyMiddleware wraps base in middleware, the first middleware being the outermost
+func applyMiddleware(base http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	next := RoundTripFunc(base.RoundTrip)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+
+	return next
+}
@@ -0,0 +1,46 @@
+package weatherstack
+
+import "strings"
+
+type MoonPhase string
+
+const (
+	MoonPhaseUnknown        MoonPhase = ""
+	MoonPhaseNewMoon        MoonPhase = "New Moon"
+	MoonPhaseWaxingCrescent MoonPhase = "Waxing Crescent"
+	MoonPhaseFirstQuarter   MoonPhase = "First Quarter"
+	MoonPhaseWaxingGibbous  MoonPhase = "Waxing Gibbous"
+	MoonPhaseFullMoon       MoonPhase = "Full Moon"
+	MoonPhaseWaningGibbous  MoonPhase = "Waning Gibbous"
+	MoonPhaseLastQuarter    MoonPhase = "Last Quarter"
+	MoonPhaseWaningCrescent MoonPhase = "Waning Crescent"
+)
+
+var moonPhases = []MoonPhase{
+	MoonPhaseNewMoon,
+	MoonPhaseWaxingCrescent,
+	MoonPhaseFirstQuarter,
+	MoonPhaseWaxingGibbous,
+	MoonPhaseFullMoon,
+	MoonPhaseWaningGibbous,
+	MoonPhaseLastQuarter,
+	MoonPhaseWaningCrescent,
+}
+
+// Phase parses MoonPhase (case-insensitive, trimmed), returning MoonPhaseUnknown for unrecognized values.
+// "Third Quarter" is recognized as MoonPhaseLastQuarter.
+func (a Astro) Phase() MoonPhase {
+	phase := strings.Join(strings.Fields(a.MoonPhase), " ")
+
+	if strings.EqualFold(phase, "Third Quarter") {
+		return MoonPhaseLastQuarter
+	}
+
+	for _, moonPhase := range moonPhases {
+		if strings.EqualFold(phase, string(moonPhase)) {
+			return moonPhase
+		}
+	}
+
+	return MoonPhaseUnknown
+}
@@ -0,0 +1,250 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// NormalizedSchemaVersion is incremented on every incompatible change of the normalized schema
+const NormalizedSchemaVersion int = 1
+
+// NormalizedResponse is a response in a stable schema independent of the Weatherstack format, see MarshalNormalized.
+// Values are converted to the units of NormalizedUnits regardless of the units they were requested in.
+// Timestamps are ISO-8601 (RFC 3339) in the location's time zone, without offset if the time zone is unknown.
+type NormalizedResponse struct {
+	SchemaVersion int                     `json:"schema_version"`
+	Location      NormalizedLocation      `json:"location"`
+	Units         NormalizedUnits         `json:"units"`
+	Current       *NormalizedObservation  `json:"current,omitempty"`
+	Days          []NormalizedDay         `json:"days"`
+	Hourly        []NormalizedObservation `json:"hourly"`
+}
+
+type NormalizedLocation struct {
+	Name           string  `json:"name"`
+	Country        string  `json:"country"`
+	Region         string  `json:"region"`
+	Lat            float64 `json:"lat"`
+	Lon            float64 `json:"lon"`
+	TimeZone       string  `json:"time_zone"`
+	UTCOffsetHours float64 `json:"utc_offset_hours"`
+}
+
+type NormalizedUnits struct {
+	Temperature   string `json:"temperature"`
+	WindSpeed     string `json:"wind_speed"`
+	Precipitation string `json:"precipitation"`
+	Snow          string `json:"snow"`
+	Pressure      string `json:"pressure"`
+	Visibility    string `json:"visibility"`
+}
+
+// normalizedUnits are the units all normalized values are in
+var normalizedUnits = NormalizedUnits{
+	Temperature:   "celsius",
+	WindSpeed:     "km/h",
+	Precipitation: "mm",
+	Snow:          "cm",
+	Pressure:      "mb",
+	Visibility:    "km",
+}
+
+// NormalizedDay is a day of a historical or forecast response, Sunrise and Sunset are omitted when they do not occur
+type NormalizedDay struct {
+	Date           string  `json:"date"`
+	MinTemperature float64 `json:"min_temperature"`
+	MaxTemperature float64 `json:"max_temperature"`
+	AvgTemperature float64 `json:"avg_temperature"`
+	TotalSnow      float64 `json:"total_snow"`
+	SunHours       float64 `json:"sun_hours"`
+	UVIndex        int64   `json:"uv_index"`
+	Sunrise        string  `json:"sunrise,omitempty"`
+	Sunset         string  `json:"sunset,omitempty"`
+}
+
+// NormalizedObservation is the current weather or an hourly record. Dewpoint and WindGust are only set for hourly records,
+// the pointer fields are omitted when Weatherstack did not return them.
+type NormalizedObservation struct {
+	Time          string   `json:"time"`
+	Temperature   float64  `json:"temperature"`
+	FeelsLike     float64  `json:"feels_like"`
+	Dewpoint      *float64 `json:"dewpoint,omitempty"`
+	WindSpeed     float64  `json:"wind_speed"`
+	WindGust      *float64 `json:"wind_gust,omitempty"`
+	WindDegree    int64    `json:"wind_degree"`
+	WindDir       string   `json:"wind_dir"`
+	WeatherCode   int      `json:"weather_code"`
+	Description   string   `json:"description"`
+	Precipitation float64  `json:"precipitation"`
+	Humidity      int64    `json:"humidity"`
+	Pressure      float64  `json:"pressure"`
+	Cloudcover    int64    `json:"cloudcover"`
+	Visibility    float64  `json:"visibility"`
+	UVIndex       *int64   `json:"uv_index,omitempty"`
+}
+
+// Normalized converts the response into the normalized schema
+func (r *CurrentResponse) Normalized() NormalizedResponse {
+	normalized := newNormalizedResponse(r.Location, nil)
+
+	current := r.Current
+	observation := NormalizedObservation{
+		Temperature:   current.TemperatureValue().Celsius(),
+		FeelsLike:     current.FeelsLikeValue().Celsius(),
+		WindSpeed:     current.WindSpeedValue().KmH(),
+		WindDegree:    current.WindDegree.Value(),
+		WindDir:       current.WindDir,
+		WeatherCode:   int(current.WeatherCode),
+		Description:   strings.Join(current.WeatherDescriptions, ", "),
+		Precipitation: current.PrecipValue().Millimeters(),
+		Humidity:      current.Humidity.Value(),
+		Pressure:      current.PressureValue().Millibars(),
+		Cloudcover:    current.Cloudcover.Value(),
+		Visibility:    current.VisibilityValue().Kilometers(),
+	}
+	uvIndex := current.UVIndex.Value()
+	observation.UVIndex = &uvIndex
+	if localTime, err := r.Location.LocalTime(); err == nil {
+		observation.Time = localTime.Format(time.RFC3339)
+	}
+	normalized.Current = &observation
+
+	return normalized
+}
+
+// Normalized converts the response into the normalized schema, days and hourly records in chronological order
+func (r *HistoricalResponse) Normalized() NormalizedResponse {
+	return newNormalizedResponse(r.Location, r.Historical)
+}
+
+// Normalized converts the response into the normalized schema, days and hourly records in chronological order.
+// The current weather is not included, request it through CurrentResponse if needed.
+func (r *ForecastResponse) Normalized() NormalizedResponse {
+	return newNormalizedResponse(r.Location, r.Forecast)
+}
+
+// MarshalNormalized returns the response as JSON in the normalized schema
+func (r *CurrentResponse) MarshalNormalized() ([]byte, *errortools.Error) {
+	return marshalNormalized(r.Normalized())
+}
+
+// MarshalNormalized returns the response as JSON in the normalized schema
+func (r *HistoricalResponse) MarshalNormalized() ([]byte, *errortools.Error) {
+	return marshalNormalized(r.Normalized())
+}
+
+// MarshalNormalized returns the response as JSON in the normalized schema
+func (r *ForecastResponse) MarshalNormalized() ([]byte, *errortools.Error) {
+	return marshalNormalized(r.Normalized())
+}
+
+func marshalNormalized(normalized NormalizedResponse) ([]byte, *errortools.Error) {
+	b, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, errortools.ErrorMessage(err)
+	}
+
+	return b, nil
+}
+
+func newNormalizedResponse(location Location, days map[string]Weather) NormalizedResponse {
+	loc, _ := location.Location()
+
+	normalized := NormalizedResponse{
+		SchemaVersion: NormalizedSchemaVersion,
+		Location: NormalizedLocation{
+			Name:           location.Name,
+			Country:        location.Country,
+			Region:         location.Region,
+			Lat:            float64(location.Lat),
+			Lon:            float64(location.Lon),
+			TimeZone:       location.TimezoneID,
+			UTCOffsetHours: float64(location.UTCOffset),
+		},
+		Units:  normalizedUnits,
+		Days:   []NormalizedDay{},
+		Hourly: []NormalizedObservation{},
+	}
+
+	for _, date := range sortedDates(days) {
+		day := days[date.String()]
+
+		normalizedDay := NormalizedDay{
+			Date:           date.String(),
+			MinTemperature: day.MinTempValue().Celsius(),
+			MaxTemperature: day.MaxTempValue().Celsius(),
+			AvgTemperature: day.AvgTempValue().Celsius(),
+			TotalSnow:      snowToCm(day.TotalSnow.Value(), day.units),
+			SunHours:       day.SunHour.Value(),
+			UVIndex:        day.UVIndex.Value(),
+		}
+		if sunrise, err := day.Astro.SunriseTime(date, loc); err == nil {
+			normalizedDay.Sunrise = formatNormalizedTime(sunrise, loc)
+		}
+		if sunset, err := day.Astro.SunsetTime(date, loc); err == nil {
+			normalizedDay.Sunset = formatNormalizedTime(sunset, loc)
+		}
+		normalized.Days = append(normalized.Days, normalizedDay)
+
+		for _, hourly := range day.Hourly {
+			normalized.Hourly = append(normalized.Hourly, normalizedHourly(hourly, date, loc))
+		}
+	}
+
+	return normalized
+}
+
+func normalizedHourly(hourly HourlyWeather, date civil.Date, loc *time.Location) NormalizedObservation {
+	observation := NormalizedObservation{
+		Temperature:   hourly.TemperatureValue().Celsius(),
+		FeelsLike:     hourly.FeelsLikeValue().Celsius(),
+		WindSpeed:     hourly.WindSpeedValue().KmH(),
+		WindDegree:    hourly.WindDegree.Value(),
+		WindDir:       hourly.WindDir,
+		WeatherCode:   int(hourly.WeatherCode),
+		Description:   strings.Join(hourly.WeatherDescriptions, ", "),
+		Precipitation: hourly.PrecipValue().Millimeters(),
+		Humidity:      hourly.Humidity.Value(),
+		Pressure:      hourly.PressureValue().Millibars(),
+		Cloudcover:    hourly.Cloudcover.Value(),
+		Visibility:    hourly.VisibilityValue().Kilometers(),
+		UVIndex:       hourly.UVIndex.Ptr(),
+	}
+
+	if hourly.Dewpoint.Valid {
+		dewpoint := hourly.DewpointValue().Celsius()
+		observation.Dewpoint = &dewpoint
+	}
+	if hourly.Windgust.Valid {
+		windGust := hourly.WindgustValue().KmH()
+		observation.WindGust = &windGust
+	}
+
+	if t, err := hourly.TimeOn(date, loc); err == nil {
+		observation.Time = formatNormalizedTime(t, loc)
+	}
+
+	return observation
+}
+
+// formatNormalizedTime formats t as RFC 3339, leaving out the offset if the time zone is unknown
+func formatNormalizedTime(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		return t.Format("2006-01-02T15:04:05")
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// snowToCm converts an amount of snow returned by the API in the given units (centimeters, or inches for UnitsFahrenheit) to cm
+func snowToCm(value float64, units Units) float64 {
+	if units == UnitsFahrenheit {
+		return value * 2.54
+	}
+
+	return value
+}
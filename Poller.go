@@ -0,0 +1,113 @@
+package weatherstack
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+type PollerConfig struct {
+	Configs       []GetCurrentWeatherConfig // fetched in order each round
+	Interval      time.Duration             // between the start of two rounds
+	Jitter        *time.Duration            // random delay of up to Jitter added to each round, defaults to none
+	SkipUnchanged bool                      // skip results whose observation_time equals the previous result for the same config
+}
+
+// PollResult is the outcome of fetching Configs[Index] in a round
+type PollResult struct {
+	Index    int
+	Config   GetCurrentWeatherConfig
+	Response *CurrentResponse
+	Error    *RequestError
+}
+
+// Poller periodically fetches the current weather, create one with Service.NewPoller
+type Poller struct {
+	service *Service
+	config  PollerConfig
+}
+
+func (service *Service) NewPoller(config PollerConfig) (*Poller, *errortools.Error) {
+	if len(config.Configs) == 0 {
+		return nil, errortools.ErrorMessage("No Configs provided")
+	}
+
+	if config.Interval <= 0 {
+		return nil, errortools.ErrorMessage("Interval must be positive")
+	}
+
+	if config.Jitter != nil && *config.Jitter < 0 {
+		return nil, errortools.ErrorMessage("Jitter must not be negative")
+	}
+
+	return &Poller{
+		service: service,
+		config:  config,
+	}, nil
+}
+
+// Run polls until ctx is done, invoking handler with each result from the calling goroutine.
+// The first round starts immediately.
+func (poller *Poller) Run(ctx context.Context, handler func(result PollResult)) {
+	observed := make([]*time.Time, len(poller.config.Configs))
+
+	for {
+		started := time.Now()
+
+		for i, config := range poller.config.Configs {
+			if ctx.Err() != nil {
+				return
+			}
+
+			response, e := poller.service.GetCurrentWeatherWithContext(ctx, config)
+			if e != nil && ctx.Err() != nil {
+				return
+			}
+
+			if e == nil && poller.config.SkipUnchanged {
+				observationTime := time.Time(response.Current.ObservationTime)
+				if observed[i] != nil && observed[i].Equal(observationTime) {
+					continue
+				}
+				observed[i] = &observationTime
+			}
+
+			handler(PollResult{
+				Index:    i,
+				Config:   config,
+				Response: response,
+				Error:    e,
+			})
+		}
+
+		delay := poller.config.Interval - time.Since(started)
+		if poller.config.Jitter != nil && *poller.config.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(*poller.config.Jitter)))
+		}
+
+		if !sleep(ctx, delay) {
+			return
+		}
+	}
+}
+
+// Start polls in a new goroutine until ctx is done, the returned channel being closed afterwards.
+// Results are delivered unbuffered, a slow receiver delays the next fetch.
+func (poller *Poller) Start(ctx context.Context) <-chan PollResult {
+	results := make(chan PollResult)
+
+	go func() {
+		defer close(results)
+
+		poller.Run(ctx, func(result PollResult) {
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return results
+}
@@ -0,0 +1,202 @@
+package weatherstack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"cloud.google.com/go/civil"
+)
+
+type QualityIssueKind string
+
+const (
+	QualityIssueMissingDate      QualityIssueKind = "missing_date"      // a requested date is not in the response
+	QualityIssueUnexpectedDate   QualityIssueKind = "unexpected_date"   // a date outside the requested range is in the response
+	QualityIssueNoData           QualityIssueKind = "no_data"           // the day holds no data, see HistoricalWeather.HasData
+	QualityIssueMissingHours     QualityIssueKind = "missing_hours"     // fewer hourly records than the interval implies
+	QualityIssueDuplicateTime    QualityIssueKind = "duplicate_time"    // multiple hourly records of the same day share a Time
+	QualityIssueInvalidTime      QualityIssueKind = "invalid_time"      // an hourly record has a Time that is no valid hour code
+	QualityIssueImplausibleValue QualityIssueKind = "implausible_value" // a value outside its physically possible range
+	QualityIssueTemperatureSpike QualityIssueKind = "temperature_spike" // the temperature changes more than MaxSpikeCelsius between consecutive records
+)
+
+// QualityIssue is a suspicious part of a response. Time is the hour code of the hourly record, empty for issues of a day.
+type QualityIssue struct {
+	Kind    QualityIssueKind
+	Date    string
+	Time    string
+	Field   string // json name of the field, for implausible values and spikes
+	Value   float64
+	Message string
+}
+
+type QualityReport struct {
+	Issues []QualityIssue // date issues first, then the issues per day in chronological order
+}
+
+// OK reports whether no issues were found
+func (report QualityReport) OK() bool {
+	return len(report.Issues) == 0
+}
+
+// Count returns the number of issues of kind
+func (report QualityReport) Count(kind QualityIssueKind) int {
+	count := 0
+	for _, issue := range report.Issues {
+		if issue.Kind == kind {
+			count++
+		}
+	}
+
+	return count
+}
+
+type QualityConfig struct {
+	StartDate       *civil.Date // first requested date, dates not being checked against the request if nil
+	EndDate         *civil.Date // last requested date, defaults to StartDate
+	Interval        *Interval   // interval requested, hourly record counts not being checked if nil
+	MaxSpikeCelsius *float64    // defaults to 15
+}
+
+// plausible temperature range (°C), a little beyond the recorded extremes
+const (
+	qualityMinCelsius float64 = -90
+	qualityMaxCelsius float64 = 60
+)
+
+// QualityReport scans the response for missing or unexpected dates, missing and duplicate hours and
+// physically implausible values, temperatures being compared in °C regardless of the units requested
+func (r *HistoricalResponse) QualityReport(config QualityConfig) QualityReport {
+	maxSpike := 15.0
+	if config.MaxSpikeCelsius != nil {
+		maxSpike = *config.MaxSpikeCelsius
+	}
+
+	report := QualityReport{Issues: []QualityIssue{}}
+	add := func(kind QualityIssueKind, date string, time string, field string, value float64, format string, a ...interface{}) {
+		report.Issues = append(report.Issues, QualityIssue{
+			Kind:    kind,
+			Date:    date,
+			Time:    time,
+			Field:   field,
+			Value:   value,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	if config.StartDate != nil {
+		endDate := *config.StartDate
+		if config.EndDate != nil {
+			endDate = *config.EndDate
+		}
+
+		for date := *config.StartDate; !date.After(endDate); date = date.AddDays(1) {
+			if _, ok := r.Historical[date.String()]; !ok {
+				add(QualityIssueMissingDate, date.String(), "", "", 0, "Date %s not in response", date)
+			}
+		}
+
+		for _, date := range sortedDates(r.Historical) {
+			if date.Before(*config.StartDate) || date.After(endDate) {
+				add(QualityIssueUnexpectedDate, date.String(), "", "", 0, "Date %s not requested", date)
+			}
+		}
+	}
+
+	for _, date := range sortedDates(r.Historical) {
+		day := r.Historical[date.String()]
+		d := date.String()
+
+		if !day.HasData() {
+			add(QualityIssueNoData, d, "", "", 0, "No data for %s", d)
+			continue
+		}
+
+		if config.Interval != nil && !day.HourlyComplete(*config.Interval) {
+			add(QualityIssueMissingHours, d, "", "", float64(len(day.Hourly)),
+				"%v hourly records for %s, expected %v", len(day.Hourly), d, config.Interval.ExpectedHourlyCount())
+		}
+
+		minTemp, maxTemp := temperatureToCelsius(day.MinTemp.Value(), day.units), temperatureToCelsius(day.MaxTemp.Value(), day.units)
+		if minTemp > maxTemp {
+			add(QualityIssueImplausibleValue, d, "", "mintemp", day.MinTemp.Value(), "mintemp %v above maxtemp %v on %s", day.MinTemp.Value(), day.MaxTemp.Value(), d)
+		}
+		for _, check := range []qualityCheck{{"mintemp", minTemp, qualityMinCelsius, qualityMaxCelsius}, {"maxtemp", maxTemp, qualityMinCelsius, qualityMaxCelsius}} {
+			if check.value < check.min || check.value > check.max {
+				add(QualityIssueImplausibleValue, d, "", check.field, check.value, "%s %.1f °C on %s", check.field, check.value, d)
+			}
+		}
+
+		hours := append([]HourlyWeather{}, day.Hourly...)
+		sort.SliceStable(hours, func(i, j int) bool {
+			return hours[i].Time < hours[j].Time
+		})
+
+		seen := map[int64]bool{}
+		var previous *HourlyWeather
+
+		for i := range hours {
+			hourly := hours[i]
+			t := fmt.Sprintf("%v", int64(hourly.Time))
+
+			if _, err := hourly.TimeOfDay(); err != nil {
+				add(QualityIssueInvalidTime, d, t, "time", float64(hourly.Time), "Invalid time %s on %s", t, d)
+				continue
+			}
+
+			if seen[int64(hourly.Time)] {
+				add(QualityIssueDuplicateTime, d, t, "time", float64(hourly.Time), "Duplicate time %s on %s", t, d)
+				continue
+			}
+			seen[int64(hourly.Time)] = true
+
+			for _, check := range hourlyQualityChecks(hourly) {
+				if check.value < check.min || check.value > check.max {
+					add(QualityIssueImplausibleValue, d, t, check.field, check.value,
+						"%s %v at %s on %s outside [%v,%v]", check.field, check.value, t, d, check.min, check.max)
+				}
+			}
+
+			if previous != nil {
+				spike := hourly.TemperatureValue().Celsius() - previous.TemperatureValue().Celsius()
+				if math.Abs(spike) > maxSpike {
+					add(QualityIssueTemperatureSpike, d, t, "temperature", spike,
+						"Temperature changes %.1f °C from %v to %s on %s", spike, int64(previous.Time), t, d)
+				}
+			}
+			previous = &hours[i]
+		}
+	}
+
+	return report
+}
+
+type qualityCheck struct {
+	field    string
+	value    float64
+	min, max float64
+}
+
+// hourlyQualityChecks returns the values of hourly with their plausible range, temperatures in °C
+func hourlyQualityChecks(hourly HourlyWeather) []qualityCheck {
+	checks := []qualityCheck{
+		{"temperature", hourly.TemperatureValue().Celsius(), qualityMinCelsius, qualityMaxCelsius},
+		{"humidity", float64(hourly.Humidity.Value()), 0, 100},
+		{"cloudcover", float64(hourly.Cloudcover.Value()), 0, 100},
+		{"precip", hourly.Precip.Value(), 0, math.Inf(1)},
+		{"wind_speed", hourly.WindSpeed.Value(), 0, math.Inf(1)},
+		{"wind_degree", float64(hourly.WindDegree.Value()), 0, 360},
+		{"pressure", hourly.Pressure.Value(), 0, math.Inf(1)},
+		{"visibility", hourly.Visibility.Value(), 0, math.Inf(1)},
+	}
+
+	if hourly.UVIndex.Valid {
+		checks = append(checks, qualityCheck{"uv_index", float64(hourly.UVIndex.Value()), 0, math.Inf(1)})
+	}
+	if hourly.ChanceOfRain.Valid {
+		checks = append(checks, qualityCheck{"chanceofrain", float64(hourly.ChanceOfRain.Value()), 0, 100})
+	}
+
+	return checks
+}
@@ -0,0 +1,106 @@
+package weatherstack
+
+import (
+	"net"
+	"strings"
+	"unicode"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// QueryAutoIP lets Weatherstack geolocate the IP address the request originates from
+const QueryAutoIP string = "fetch:ip"
+
+// QueryFromIP returns the query for the location of ip, an empty string if ip is nil
+func QueryFromIP(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
+
+// validateQuery rejects "fetch:" queries other than QueryAutoIP, queries containing ';' (which Weatherstack
+// treats as a bulk query of multiple locations, see GetHistoricalWeatherMulti) and control characters.
+// Other characters such as '&' and '=' are escaped when the URL is built, so they cannot add parameters.
+func validateQuery(query string) *errortools.Error {
+	if strings.Contains(query, ";") {
+		return errortools.ErrorMessagef("Invalid Query: %s, must not contain ';'", query)
+	}
+
+	if strings.IndexFunc(query, unicode.IsControl) >= 0 {
+		return errortools.ErrorMessagef("Invalid Query: %q, must not contain control characters", query)
+	}
+
+	if strings.HasPrefix(strings.ToLower(query), "fetch:") && query != QueryAutoIP {
+		return errortools.ErrorMessagef("Invalid Query: %s, only %s is supported", query, QueryAutoIP)
+	}
+
+	return nil
+}
+
+// Query is a validated value for the Query field of the configs, pass it as q.String()
+type Query string
+
+func (q Query) String() string {
+	return string(q)
+}
+
+// QueryCity returns the query for a city name, optionally qualified by region or country, e.g. "New York" or "London, United Kingdom"
+func QueryCity(name string) (Query, *errortools.Error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", errortools.ErrorMessage("City name must not be empty")
+	}
+
+	if strings.Contains(name, ";") {
+		return "", errortools.ErrorMessagef("City name %s must not contain ';'", name)
+	}
+
+	return Query(name), nil
+}
+
+// QueryLatLon returns the query for coordinates, formatted with at most 6 decimals
+func QueryLatLon(lat float64, lon float64) (Query, *errortools.Error) {
+	coordinates := Coordinates{Lat: lat, Lon: lon}
+
+	e := coordinates.Validate()
+	if e != nil {
+		return "", e
+	}
+
+	return Query(coordinates.Query()), nil
+}
+
+// QueryIP returns the query for the location of an IPv4 or IPv6 address
+func QueryIP(ip string) (Query, *errortools.Error) {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return "", errortools.ErrorMessagef("Invalid IP address: %s", ip)
+	}
+
+	return Query(QueryFromIP(parsed)), nil
+}
+
+// QueryZip returns the query for a zip or postal code, e.g. "99501" or "SW1A 1AA"
+func QueryZip(zip string) (Query, *errortools.Error) {
+	zip = strings.TrimSpace(zip)
+
+	if zip == "" {
+		return "", errortools.ErrorMessage("Zip code must not be empty")
+	}
+
+	for _, r := range zip {
+		if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r == ' ' || r == '-') {
+			return "", errortools.ErrorMessagef("Invalid zip code: %s", zip)
+		}
+	}
+
+	return Query(zip), nil
+}
+
+// QueryFetchIP returns QueryAutoIP
+func QueryFetchIP() Query {
+	return Query(QueryAutoIP)
+}
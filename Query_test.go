@@ -0,0 +1,86 @@
+package weatherstack
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestIPQueryParameter(t *testing.T) {
+	service, e := NewService(&ServiceConfig{AccessKey: "key"})
+	if e != nil {
+		t.Fatal(e.Message())
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"auto ip", QueryAutoIP, "fetch:ip"},
+		{"ipv4", QueryFromIP(net.ParseIP("134.201.250.155")), "134.201.250.155"},
+		{"ipv6", QueryFromIP(net.ParseIP("2001:0db8:0000:0000:0000:ff00:0042:8329")), "2001:db8::ff00:42:8329"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rawURL, e := service.currentURL(GetCurrentWeatherConfig{Query: test.query})
+			if e != nil {
+				t.Fatalf("currentURL() error = %s", e.Message())
+			}
+
+			_url, err := url.Parse(rawURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := _url.Query().Get("query"); got != test.want {
+				t.Errorf("query = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestQueryFromIPNil(t *testing.T) {
+	if got := QueryFromIP(nil); got != "" {
+		t.Errorf("QueryFromIP(nil) = %q, want \"\"", got)
+	}
+}
+
+func TestValidateFetchQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{QueryAutoIP, false},
+		{"134.201.250.155", false},
+		{"fetch:location", true},
+		{"FETCH:IP", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			if e := validateQuery(test.query); (e != nil) != test.wantErr {
+				t.Errorf("validateQuery(%q) error = %v, want error %v", test.query, e, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchLocationsValidatesQuery(t *testing.T) {
+	requests := 0
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}, ServiceConfig{})
+
+	for _, query := range []string{"", " ", "Amsterdam;Paris", "fetch:location"} {
+		if _, e := service.SearchLocations(query); e == nil {
+			t.Errorf("SearchLocations(%q) returned no error", query)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("got %v requests, want 0", requests)
+	}
+}
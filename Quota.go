@@ -0,0 +1,47 @@
+package weatherstack
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrQuotaExhausted is the error with which requests are refused once ServiceConfig.MaxRequests is reached
+var ErrQuotaExhausted = errors.New("client-side request quota exhausted")
+
+// reserveRequest reserves a request within MaxRequests, returning false if the quota is exhausted
+func (service *Service) reserveRequest() bool {
+	if service.maxRequests == nil {
+		return true
+	}
+
+	if atomic.AddInt64(&service.requestsUsed, 1) > *service.maxRequests {
+		atomic.AddInt64(&service.requestsUsed, -1)
+		return false
+	}
+
+	return true
+}
+
+// settleRequest counts a successful request, releasing the reservation of a failed one
+func (service *Service) settleRequest(success bool) {
+	if service.maxRequests == nil {
+		if success {
+			atomic.AddInt64(&service.requestsUsed, 1)
+		}
+		return
+	}
+
+	if !success {
+		atomic.AddInt64(&service.requestsUsed, -1)
+	}
+}
+
+// RequestsUsed returns the number of successful requests
+func (service *Service) RequestsUsed() int64 {
+	return atomic.LoadInt64(&service.requestsUsed)
+}
+
+// ResetRequestsUsed resets the number of successful requests, e.g. at the start of a new billing month
+func (service *Service) ResetRequestsUsed() {
+	atomic.StoreInt64(&service.requestsUsed, 0)
+}
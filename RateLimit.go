@@ -0,0 +1,76 @@
+package weatherstack
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int // number of requests that may be sent at once after being idle, defaults to 1
+}
+
+// rateLimiter is a token bucket shared by all requests of a service.
+// A nil *rateLimiter does not limit.
+type rateLimiter struct {
+	interval time.Duration
+	burst    int
+	mutex    sync.Mutex
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(config *RateLimitConfig) (*rateLimiter, *errortools.Error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	if config.RequestsPerSecond <= 0 {
+		return nil, errortools.ErrorMessage("RateLimit.RequestsPerSecond must be greater than 0")
+	}
+
+	burst := config.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / config.RequestsPerSecond),
+		burst:    burst,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}, nil
+}
+
+// wait blocks until a request may be sent, returning false if ctx is done first
+func (limiter *rateLimiter) wait(ctx context.Context) bool {
+	if limiter == nil {
+		return true
+	}
+
+	return sleep(ctx, limiter.reserve())
+}
+
+// reserve takes a token and returns the delay until it is available
+func (limiter *rateLimiter) reserve() time.Duration {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := time.Now()
+
+	limiter.tokens += float64(now.Sub(limiter.last)) / float64(limiter.interval)
+	if limiter.tokens > float64(limiter.burst) {
+		limiter.tokens = float64(limiter.burst)
+	}
+	limiter.last = now
+
+	limiter.tokens--
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens * float64(limiter.interval))
+}
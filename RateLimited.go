@@ -0,0 +1,51 @@
+package weatherstack
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitedError is the message of the error returned when Weatherstack throttles a request (HTTP 429),
+// the delay it asks for is also set as ErrorExtraRetryAfter (in seconds)
+type RateLimitedError struct {
+	RetryAfter time.Duration // 0 if Weatherstack did not specify it
+}
+
+func (err *RateLimitedError) Error() string {
+	if err.RetryAfter == 0 {
+		return "rate limited by Weatherstack"
+	}
+
+	return fmt.Sprintf("rate limited by Weatherstack, retry after %s", err.RetryAfter)
+}
+
+// retryAfter returns the delay of the Retry-After header of a 429 or 503 response (in seconds or as HTTP date),
+// 0 if there is none
+func retryAfter(response *http.Response) time.Duration {
+	if response == nil || (response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable) {
+		return 0
+	}
+
+	header := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
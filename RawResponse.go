@@ -0,0 +1,57 @@
+package weatherstack
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ResponseMeta holds the HTTP metadata of a response, safe to log
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+	URL        string        // the URL requested, with the access key redacted
+	Duration   time.Duration // of the attempt that succeeded, excluding earlier attempts
+}
+
+// redactURL returns rawURL with the value of the access_key parameter replaced by "REDACTED"
+func redactURL(rawURL string) string {
+	_url, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	query := _url.Query()
+	if query.Get("access_key") != "" {
+		query.Set("access_key", "REDACTED")
+		_url.RawQuery = query.Encode()
+	}
+
+	return _url.String()
+}
+
+// rawResponseSetter is implemented by response models that keep the raw response body and metadata,
+// meta being nil for responses served from the cache
+type rawResponseSetter interface {
+	setRawResponse(rawResponse []byte, meta *ResponseMeta)
+}
+
+func (r *CurrentResponse) setRawResponse(rawResponse []byte, meta *ResponseMeta) {
+	r.RawResponse, r.Meta = rawResponse, meta
+}
+
+func (r *HistoricalResponse) setRawResponse(rawResponse []byte, meta *ResponseMeta) {
+	r.RawResponse, r.Meta = rawResponse, meta
+}
+
+func (r *ForecastResponse) setRawResponse(rawResponse []byte, meta *ResponseMeta) {
+	r.RawResponse, r.Meta = rawResponse, meta
+}
+
+func (r *MarineResponse) setRawResponse(rawResponse []byte, meta *ResponseMeta) {
+	r.RawResponse, r.Meta = rawResponse, meta
+}
+
+func (r *AutocompleteResponse) setRawResponse(rawResponse []byte, meta *ResponseMeta) {
+	r.RawResponse, r.Meta = rawResponse, meta
+}
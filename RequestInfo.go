@@ -0,0 +1,47 @@
+package weatherstack
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a completed request attempt, as passed to ServiceConfig.OnRequest
+type RequestInfo struct {
+	Endpoint          string
+	URL               string // without access key
+	Attempt           int    // 1 for the first attempt
+	Duration          time.Duration
+	StatusCode        int // 0 if no response was received
+	WeatherstackError *WeatherstackError
+	Error             *RequestError
+	RetryDelay        time.Duration // delay before the next attempt, 0 if the request is not retried
+}
+
+// newRequestInfo builds the info of an attempt, _url must not contain the access key
+func newRequestInfo(_url *url.URL, attempt int, duration time.Duration, response *http.Response, apiError *WeatherstackError, e *RequestError, retryDelay time.Duration) RequestInfo {
+	info := RequestInfo{
+		Endpoint:          strings.TrimPrefix(_url.Path, "/"),
+		URL:               _url.String(),
+		Attempt:           attempt,
+		Duration:          duration,
+		WeatherstackError: apiError,
+		Error:             e,
+		RetryDelay:        retryDelay,
+	}
+
+	if response != nil {
+		info.StatusCode = response.StatusCode
+	}
+
+	return info
+}
+
+func (service *Service) notifyRequest(info RequestInfo) {
+	service.logAttempt(info)
+
+	if service.onRequest != nil {
+		service.onRequest(info)
+	}
+}
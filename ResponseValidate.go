@@ -0,0 +1,83 @@
+package weatherstack
+
+import (
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// Validate checks that the core fields of the response are populated,
+// catching responses that came back without an error object but contain no data (e.g. for an ambiguous query)
+func (r *CurrentResponse) Validate() *errortools.Error {
+	e := validateLocation(r.Request.Query, r.Location)
+	if e != nil {
+		return e
+	}
+
+	if len(r.Current.WeatherDescriptions) == 0 {
+		return errortools.ErrorMessagef("Response for query '%s' contains no current weather", r.Request.Query)
+	}
+
+	return nil
+}
+
+func (r *HistoricalResponse) Validate() *errortools.Error {
+	e := validateLocation(r.Request.Query, r.Location)
+	if e != nil {
+		return e
+	}
+
+	if len(r.Historical) == 0 {
+		return errortools.ErrorMessagef("Response for query '%s' contains no historical weather", r.Request.Query)
+	}
+
+	return nil
+}
+
+func (r *ForecastResponse) Validate() *errortools.Error {
+	e := validateLocation(r.Request.Query, r.Location)
+	if e != nil {
+		return e
+	}
+
+	if len(r.Forecast) == 0 {
+		return errortools.ErrorMessagef("Response for query '%s' contains no forecast", r.Request.Query)
+	}
+
+	return nil
+}
+
+func (r *MarineResponse) Validate() *errortools.Error {
+	e := validateLocation(r.Request.Query, r.Location)
+	if e != nil {
+		return e
+	}
+
+	if len(r.Marine) == 0 {
+		return errortools.ErrorMessagef("Response for query '%s' contains no marine weather", r.Request.Query)
+	}
+
+	return nil
+}
+
+// Validate of AutocompleteResponse accepts an empty Results, a query without matches being a valid outcome
+func (r *AutocompleteResponse) Validate() *errortools.Error {
+	if r.Request.Query == "" {
+		return errortools.ErrorMessage("Response contains no request")
+	}
+
+	return nil
+}
+
+// isValidResponse reports whether responseModel passes its Validate method, models without one being valid
+func isValidResponse(responseModel interface{}) bool {
+	validator, ok := responseModel.(interface{ Validate() *errortools.Error })
+
+	return !ok || validator.Validate() == nil
+}
+
+func validateLocation(query string, location Location) *errortools.Error {
+	if location.Name == "" {
+		return errortools.ErrorMessagef("Response for query '%s' contains no location", query)
+	}
+
+	return nil
+}
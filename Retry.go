@@ -0,0 +1,87 @@
+package weatherstack
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryConfig configures the retrying of requests that failed due to a network error, a 5xx response or
+// rate limiting (HTTP 429). Weatherstack API errors (e.g. invalid access key, invalid query, monthly usage limit reached)
+// are deterministic and never retried.
+type RetryConfig struct {
+	MaxAttempts int           // including the first attempt, defaults to 3, 1 disables retrying
+	BaseDelay   time.Duration // delay before the first retry, doubled for each next retry, defaults to 500 milliseconds
+	MaxDelay    time.Duration // defaults to 10 seconds
+}
+
+func newRetryConfig(config *RetryConfig) RetryConfig {
+	retryConfig := RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+
+	if config == nil {
+		return retryConfig
+	}
+
+	if config.MaxAttempts > 0 {
+		retryConfig.MaxAttempts = config.MaxAttempts
+	}
+	if config.BaseDelay > 0 {
+		retryConfig.BaseDelay = config.BaseDelay
+	}
+	if config.MaxDelay > 0 {
+		retryConfig.MaxDelay = config.MaxDelay
+	}
+
+	return retryConfig
+}
+
+// isRetryable reports whether a failed request may succeed when retried: a network error (no response),
+// other than ErrFixtureNotFound, a 5xx response or a 429 (too many requests) response
+func isRetryable(response *http.Response, apiError *WeatherstackError, e *RequestError) bool {
+	if errors.Is(Err(e), ErrFixtureNotFound) {
+		return false
+	}
+
+	if apiError != nil {
+		return apiError.Code.IsRetryable()
+	}
+
+	if response == nil {
+		return true
+	}
+
+	return response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+}
+
+// retryDelay returns the exponential backoff delay after attempt, with jitter between half and the full delay
+func (service *Service) retryDelay(attempt int) time.Duration {
+	delay := service.retry.BaseDelay
+	for i := 1; i < attempt && delay < service.retry.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > service.retry.MaxDelay {
+		delay = service.retry.MaxDelay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// sleep waits for delay, returning false if ctx is done first
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,108 @@
+package weatherstack
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// rainyDayMinPrecipMm is the daily precipitation from which a day counts as rainy
+const rainyDayMinPrecipMm float64 = 1
+
+// PeriodSummary summarizes the days of an ISO week or calendar month, in the units the data was requested in
+type PeriodSummary struct {
+	Period      string     // "2006-W01" for weeks, "2006-01" for months
+	Start       civil.Date // first day of the period
+	End         civil.Date // last day of the period
+	Days        int        // days in the response within the period
+	MinTemp     float64    // lowest daily MinTemp
+	MaxTemp     float64    // highest daily MaxTemp
+	AvgTemp     float64    // mean of the daily AvgTemp
+	TotalPrecip float64    // sum of PrecipFromHourly, days without hourly records count as zero
+	TotalSnow   float64
+	SunHours    float64
+	RainyDays   int // days with at least 1 mm of precipitation
+}
+
+// RollupWeekly summarizes the days per ISO week (Monday through Sunday) in chronological order.
+// Days are bucketed by their date, which Weatherstack reports in the location's time zone.
+func (r *HistoricalResponse) RollupWeekly() []PeriodSummary {
+	return rollup(r.Historical, func(date civil.Date) (string, civil.Date, civil.Date) {
+		year, week := date.In(time.UTC).ISOWeek()
+
+		// weekday with Monday = 0
+		weekday := (int(date.In(time.UTC).Weekday()) + 6) % 7
+		start := date.AddDays(-weekday)
+
+		return fmt.Sprintf("%04d-W%02d", year, week), start, start.AddDays(6)
+	})
+}
+
+// RollupMonthly summarizes the days per calendar month in chronological order, see RollupWeekly
+func (r *HistoricalResponse) RollupMonthly() []PeriodSummary {
+	return rollup(r.Historical, func(date civil.Date) (string, civil.Date, civil.Date) {
+		start := civil.Date{Year: date.Year, Month: date.Month, Day: 1}
+		end := civil.DateOf(start.In(time.UTC).AddDate(0, 1, -1))
+
+		return fmt.Sprintf("%04d-%02d", date.Year, int(date.Month)), start, end
+	})
+}
+
+func rollup(days map[string]Weather, period func(date civil.Date) (string, civil.Date, civil.Date)) []PeriodSummary {
+	summaries := make(map[string]*PeriodSummary)
+	avgTempSums := make(map[string]float64)
+
+	for key, day := range days {
+		date, err := civil.ParseDate(key)
+		if err != nil {
+			continue
+		}
+
+		name, start, end := period(date)
+
+		summary, ok := summaries[name]
+		if !ok {
+			summary = &PeriodSummary{
+				Period:  name,
+				Start:   start,
+				End:     end,
+				MinTemp: day.MinTemp.Value(),
+				MaxTemp: day.MaxTemp.Value(),
+			}
+			summaries[name] = summary
+		}
+
+		summary.Days++
+
+		if day.MinTemp.Value() < summary.MinTemp {
+			summary.MinTemp = day.MinTemp.Value()
+		}
+		if day.MaxTemp.Value() > summary.MaxTemp {
+			summary.MaxTemp = day.MaxTemp.Value()
+		}
+		avgTempSums[name] += day.AvgTemp.Value()
+
+		precip, _ := day.PrecipFromHourly()
+		summary.TotalPrecip += precip
+		if (Precipitation{precip, day.units}).Millimeters() >= rainyDayMinPrecipMm {
+			summary.RainyDays++
+		}
+
+		summary.TotalSnow += day.TotalSnow.Value()
+		summary.SunHours += day.SunHour.Value()
+	}
+
+	result := make([]PeriodSummary, 0, len(summaries))
+	for name, summary := range summaries {
+		summary.AvgTemp = avgTempSums[name] / float64(summary.Days)
+		result = append(result, *summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+
+	return result
+}
@@ -0,0 +1,58 @@
+package weatherstack
+
+import (
+	"reflect"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// Select projects the historical days onto the given daily fields, identified by their json name (e.g. "mintemp", "maxtemp").
+// The result is keyed by date, so the full response can be released afterwards.
+func (r *HistoricalResponse) Select(fields ...string) (map[string]map[string]interface{}, *errortools.Error) {
+	return selectFields(r.Historical, fields)
+}
+
+// Select projects the forecast days onto the given daily fields, see HistoricalResponse.Select
+func (r *ForecastResponse) Select(fields ...string) (map[string]map[string]interface{}, *errortools.Error) {
+	return selectFields(r.Forecast, fields)
+}
+
+func selectFields(days map[string]Weather, fields []string) (map[string]map[string]interface{}, *errortools.Error) {
+	weatherType := reflect.TypeOf(Weather{})
+
+	fieldIndexes := make(map[string]int)
+	for i := 0; i < weatherType.NumField(); i++ {
+		field := weatherType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		fieldIndexes[name] = i
+	}
+
+	indexes := make([]int, len(fields))
+	for i, field := range fields {
+		index, ok := fieldIndexes[field]
+		if !ok {
+			return nil, errortools.ErrorMessagef("Unknown field: %s", field)
+		}
+		indexes[i] = index
+	}
+
+	selected := make(map[string]map[string]interface{}, len(days))
+
+	for date, day := range days {
+		value := reflect.ValueOf(day)
+
+		projection := make(map[string]interface{}, len(fields))
+		for i, field := range fields {
+			projection[field] = value.Field(indexes[i]).Interface()
+		}
+
+		selected[date] = projection
+	}
+
+	return selected, nil
+}
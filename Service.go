@@ -1,28 +1,86 @@
 package weatherstack
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	errortools "github.com/leapforce-libraries/go_errortools"
 	go_http "github.com/leapforce-libraries/go_http"
 )
 
 const (
-	apiName        string = "Weatherstack"
-	apiURL         string = "https://api.weatherstack.com"
-	dateFormat     string = "2006-01-02"
-	MaxDaysPerCall int    = 60
+	apiName         string = "Weatherstack"
+	apiURL          string = "https://api.weatherstack.com"
+	dateFormat      string = "2006-01-02"
+	MaxDaysPerCall  int    = 60
+	MaxForecastDays int    = 14
 )
 
+// Service is safe for concurrent use
 type Service struct {
-	accessKey   string
-	httpService *go_http.Service
+	requestCount int64 // first fields for 64-bit alignment, accessed atomically
+	requestsUsed int64
+	accessKey    string
+	omitKey      bool
+	baseURL      string
+	httpClient   *http.Client
+	retry        RetryConfig
+	tracer       Tracer
+	metrics      MetricsHook
+	units        *Units
+	language     *Language
+	implyHourly  bool
+	includeRaw   bool
+	onRequest    func(info RequestInfo)
+	maxRequests  *int64
+	cache        *responseCache
+	rateLimiter  *rateLimiter
+	usage        *usageTracker
+	userAgent    *string
+	breaker      *circuitBreaker
+	locations    *locationCache
+	strict       *StrictConfig
+	errorHandler func(err error)
+	logger       Logger
 }
 
 type ServiceConfig struct {
-	AccessKey string
+	AccessKey          string
+	OmitAccessKey      bool         // do not send AccessKey (which may then be empty), e.g. when a gateway injects it
+	BaseURL            string       // defaults to https://api.weatherstack.com, may include a path prefix e.g. https://gateway.example.com/weatherstack
+	Scheme             *string      // "http" (required by the free plan) or "https", overrides the scheme of BaseURL
+	HTTPClient         *http.Client // defaults to a client using http.DefaultTransport
+	Retry              *RetryConfig // defaults to 3 attempts
+	Tracer             Tracer
+	Metrics            MetricsHook
+	OnRequest          func(info RequestInfo) // invoked after each attempt of each request
+	MaxRequests        *int64                 // refuse requests with ErrQuotaExhausted once this number of successful requests is reached
+	Usage              *UsageConfig           // track successful requests per calendar month, disabled if nil
+	Cache              *CacheConfig           // cache responses, disabled if nil
+	RateLimit          *RateLimitConfig       // throttle requests (including retries) client-side, unlimited if nil
+	CircuitBreaker     *CircuitBreakerConfig  // fail fast with ErrCircuitOpen during outages, disabled if nil
+	LocationCache      *LocationCacheConfig   // remember the location each query resolved to, disabled if nil
+	Fixtures           *FixturesConfig        // record responses to or replay them from files, e.g. for tests
+	UserAgent          *string                // User-Agent header sent with each request
+	Middleware         []Middleware           // applied to each request attempt, the first one being the outermost
+	Units              *Units                 // default for requests not specifying Units
+	Language           *Language              // default for requests not specifying Language
+	ImplyHourly        bool                   // set Hourly to HourlyOn for requests setting Interval but not Hourly
+	Strict             *StrictConfig          // detect responses deviating from the response models, disabled if nil
+	IncludeRawResponse bool                   // keep the raw response body in the RawResponse field of responses
+	ConnectTimeout     *time.Duration         // timeout for dialing and the TLS handshake, defaults to 30 seconds resp. 10 seconds
+	ReadTimeout        *time.Duration         // overall timeout of a request including reading the response body, defaults to none
+	ErrorHandler       func(err error)        // invoked with a *RequestError for each failed request
+	Logger             Logger                 // logs each attempt at debug level and each failed request at error level
 }
 
 func NewService(config *ServiceConfig) (*Service, *errortools.Error) {
@@ -30,51 +88,421 @@ func NewService(config *ServiceConfig) (*Service, *errortools.Error) {
 		return nil, errortools.ErrorMessage("ServiceConfig must not be a nil pointer")
 	}
 
-	if config.AccessKey == "" {
+	if config.AccessKey == "" && !config.OmitAccessKey {
 		return nil, errortools.ErrorMessage("AccessKey not provided")
 	}
 
-	httpService, e := go_http.NewService(&go_http.ServiceConfig{})
+	baseURL := apiURL
+	if config.BaseURL != "" {
+		_url, err := url.Parse(config.BaseURL)
+		if err != nil || _url.Scheme == "" || _url.Host == "" {
+			return nil, errortools.ErrorMessagef("Invalid BaseURL: %s", config.BaseURL)
+		}
+		baseURL = strings.TrimSuffix(config.BaseURL, "/")
+	}
+
+	if config.Scheme != nil {
+		if *config.Scheme != "http" && *config.Scheme != "https" {
+			return nil, errortools.ErrorMessagef("Invalid Scheme: %s", *config.Scheme)
+		}
+		baseURL = *config.Scheme + baseURL[strings.Index(baseURL, "://"):]
+	}
+
+	e := validateParameters(config.Units, nil, config.Language)
+	if e != nil {
+		return nil, e
+	}
+
+	if config.Fixtures != nil && config.Fixtures.Mode != FixtureModeRecord && config.Fixtures.Mode != FixtureModeReplay {
+		return nil, errortools.ErrorMessagef("Invalid Fixtures.Mode: %s", string(config.Fixtures.Mode))
+	}
+
+	rateLimiter, e := newRateLimiter(config.RateLimit)
 	if e != nil {
 		return nil, e
 	}
 
 	return &Service{
-		accessKey:   config.AccessKey,
-		httpService: httpService,
+		accessKey:    config.AccessKey,
+		omitKey:      config.OmitAccessKey,
+		baseURL:      baseURL,
+		httpClient:   newHTTPClient(config),
+		retry:        newRetryConfig(config.Retry),
+		tracer:       config.Tracer,
+		metrics:      config.Metrics,
+		units:        config.Units,
+		language:     config.Language,
+		implyHourly:  config.ImplyHourly,
+		includeRaw:   config.IncludeRawResponse,
+		onRequest:    config.OnRequest,
+		maxRequests:  config.MaxRequests,
+		cache:        newResponseCache(config.Cache),
+		rateLimiter:  rateLimiter,
+		usage:        newUsageTracker(config.Usage),
+		userAgent:    config.UserAgent,
+		breaker:      newCircuitBreaker(config.CircuitBreaker),
+		locations:    newLocationCache(config.LocationCache),
+		strict:       config.Strict,
+		errorHandler: config.ErrorHandler,
+		logger:       config.Logger,
 	}, nil
 }
 
-func (service *Service) httpRequest(httpMethod string, requestConfig *go_http.RequestConfig) (*http.Request, *http.Response, *errortools.Error) {
+// newHTTPClient returns a copy of config.HTTPClient (or a new client) with the timeouts of config applied.
+// ConnectTimeout is only applied to transports of type *http.Transport.
+func newHTTPClient(config *ServiceConfig) *http.Client {
+	httpClient := http.Client{}
+	if config.HTTPClient != nil {
+		httpClient = *config.HTTPClient
+	}
+
+	if httpClient.Transport == nil {
+		httpClient.Transport = http.DefaultTransport
+	}
+
+	if transport, ok := httpClient.Transport.(*http.Transport); ok && config.ConnectTimeout != nil {
+		transport = transport.Clone()
+		transport.DialContext = (&net.Dialer{
+			Timeout:   *config.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		transport.TLSHandshakeTimeout = *config.ConnectTimeout
+
+		httpClient.Transport = transport
+	}
+
+	if config.ReadTimeout != nil {
+		httpClient.Timeout = *config.ReadTimeout
+	}
+
+	if config.UserAgent != nil {
+		httpClient.Transport = &userAgentTransport{
+			userAgent: *config.UserAgent,
+			base:      httpClient.Transport,
+		}
+	}
+
+	if config.Fixtures != nil {
+		httpClient.Transport = &fixtureTransport{
+			config: *config.Fixtures,
+			base:   httpClient.Transport,
+		}
+	}
+
+	if len(config.Middleware) > 0 {
+		httpClient.Transport = applyMiddleware(httpClient.Transport, config.Middleware)
+	}
+
+	return &httpClient
+}
+
+func (service *Service) httpRequest(ctx context.Context, httpMethod string, requestConfig *go_http.RequestConfig) (*http.Request, *http.Response, *RequestError) {
 	// add API key
 	_url, err := url.Parse(requestConfig.URL)
 	if err != nil {
-		return nil, nil, errortools.ErrorMessage(err)
+		return nil, nil, service.reportError(nil, newRequestError(errortools.ErrorMessage(err), nil), nil, 0)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, service.reportError(_url, requestErrorOf(err), nil, 0)
+	}
+
+	cacheKey := fmt.Sprintf("%s %s", httpMethod, _url.String())
+	if rawResponse, ok := service.cache.get(cacheKey); ok {
+		e := service.decodeResponse(rawResponse, requestConfig.ResponseModel, nil)
+		service.observeRequest(_url, RequestMetrics{Cached: true, Success: e == nil})
+		if e != nil {
+			return nil, nil, service.reportError(_url, newRequestError(e, nil), nil, 0)
+		}
+
+		return nil, nil, nil
 	}
+
+	if service.cache.isOffline() {
+		service.observeRequest(_url, RequestMetrics{})
+
+		return nil, nil, service.reportError(_url, requestErrorOf(ErrCacheMiss), nil, 0)
+	}
+
+	if !service.breaker.allow() {
+		service.observeRequest(_url, RequestMetrics{})
+
+		return nil, nil, service.reportError(_url, requestErrorOf(ErrCircuitOpen), nil, 0)
+	}
+
+	if !service.reserveRequest() {
+		service.breaker.release()
+		service.observeRequest(_url, RequestMetrics{})
+
+		return nil, nil, service.reportError(_url, requestErrorOf(ErrQuotaExhausted), nil, 0)
+	}
+
+	if e := service.usage.allow(); e != nil {
+		service.breaker.release()
+		service.settleRequest(false)
+		service.observeRequest(_url, RequestMetrics{})
+
+		return nil, nil, service.reportError(_url, e, nil, 0)
+	}
+
+	ctx, span := service.startSpan(ctx, _url)
+
 	query := _url.Query()
-	query.Set("access_key", service.accessKey)
+	service.setAccessKey(query)
 
 	(*requestConfig).URL = fmt.Sprintf("%s://%s%s?%s", _url.Scheme, _url.Host, _url.Path, query.Encode())
 
+	var request *http.Request
+	var response *http.Response
+	var rawResponse []byte
+	var apiError *WeatherstackError
+	var e *RequestError
+
+	requestStarted := time.Now()
+
+	attempt := 1
+	for ; ; attempt++ {
+		if !service.rateLimiter.wait(ctx) {
+			e = requestErrorOf(ctx.Err())
+			break
+		}
+
+		started := time.Now()
+
+		request, response, rawResponse, e = service.doRequest(ctx, httpMethod, requestConfig)
+
+		apiError = weatherstackError(requestConfig)
+		retry := e != nil && attempt < service.retry.MaxAttempts && isRetryable(response, apiError, e)
+
+		// honor the delay Weatherstack asks for, giving up if it exceeds the maximum retry delay
+		var retryDelay time.Duration
+		if retry {
+			retryDelay = service.retryDelay(attempt)
+
+			if delay := retryAfter(response); delay > service.retry.MaxDelay {
+				retry, retryDelay = false, 0
+			} else if delay > 0 {
+				retryDelay = delay
+			}
+		}
+
+		service.notifyRequest(newRequestInfo(_url, attempt, time.Since(started), response, apiError, e, retryDelay))
+
+		if !retry || !sleep(ctx, retryDelay) {
+			break
+		}
+	}
+
+	service.settleRequest(e == nil)
+
+	// a missing fixture says nothing about the API
+	if e != nil && (ctx.Err() != nil || errors.Is(e, ErrFixtureNotFound)) {
+		service.breaker.release()
+	} else {
+		service.breaker.done(e == nil || !isRetryable(response, apiError, e))
+	}
+	if e == nil {
+		service.usage.record(_url)
+	}
+
+	// hollow responses are not cached, since historical responses for past dates would be cached forever
+	if e == nil && rawResponse != nil && isValidResponse(requestConfig.ResponseModel) {
+		service.cache.set(cacheKey, _url, rawResponse)
+	}
+
+	span.SetAttribute("weatherstack.retries", attempt-1)
+	endSpan(span, response, rawResponse, apiError, e)
+
+	metrics := RequestMetrics{
+		Duration: time.Since(requestStarted),
+		Attempts: attempt,
+		Success:  e == nil,
+	}
+	if response != nil {
+		metrics.StatusCode = response.StatusCode
+	}
+	if apiError != nil {
+		metrics.ErrorCode = apiError.Code
+	}
+	service.observeRequest(_url, metrics)
+
+	if e != nil {
+		if ctx.Err() != nil && e.Err == nil {
+			e.Err = ctx.Err()
+		}
+
+		return request, response, service.reportError(_url, e, response, attempt)
+	}
+
+	return request, response, nil
+}
+
+// doRequest sends a single request, returning the raw response body if the request succeeded
+func (service *Service) doRequest(ctx context.Context, httpMethod string, requestConfig *go_http.RequestConfig) (*http.Request, *http.Response, []byte, *RequestError) {
 	// add error model
 	errorResponse := ErrorResponse{}
 	(*requestConfig).ErrorModel = &errorResponse
 
-	request, response, e := service.httpService.HTTPRequest(httpMethod, requestConfig)
+	// decode the response body ourselves, since Weatherstack returns its error object with status 200
+	responseModel := requestConfig.ResponseModel
+	rawResponse := json.RawMessage{}
+	if responseModel != nil {
+		(*requestConfig).ResponseModel = &rawResponse
+	}
+
+	// go_http does not accept a context, so each request is sent through a client bound to ctx
+	httpClient := service.httpClientWithContext(ctx)
+	httpService, e := go_http.NewService(&go_http.ServiceConfig{
+		HTTPClient: httpClient,
+	})
+	if e != nil {
+		return nil, nil, nil, newRequestError(e, nil)
+	}
+
+	started := time.Now()
+	request, response, e := httpService.HTTPRequest(httpMethod, requestConfig)
+	duration := time.Since(started)
+	atomic.AddInt64(&service.requestCount, 1)
+
+	(*requestConfig).ResponseModel = responseModel
+
+	if e == nil && responseModel != nil && len(rawResponse) > 0 {
+		err := json.Unmarshal(rawResponse, &errorResponse)
+		if err == nil && errorResponse.Success != nil && !*errorResponse.Success {
+			e = errortools.ErrorMessage(&errorResponse.Error)
+		} else {
+			var meta *ResponseMeta
+			if response != nil {
+				meta = &ResponseMeta{
+					StatusCode: response.StatusCode,
+					Header:     response.Header,
+					URL:        redactURL(requestConfig.URL),
+					Duration:   duration,
+				}
+			}
+
+			e = service.decodeResponse(rawResponse, responseModel, meta)
+		}
+	}
+
+	if e != nil {
+		requestError := newRequestError(e, httpClient.Transport.(*contextTransport).err)
+
+		e.SetExtra(ErrorExtraURL, redactURL(requestConfig.URL))
+
+		if response != nil {
+			e.SetExtra(ErrorExtraHTTPStatusCode, strconv.Itoa(response.StatusCode))
+		}
+
+		if errorResponse.Error.Code != 0 {
+			apiError := errorResponse.Error
+			e.SetMessage(&apiError)
+			requestError.Err = &apiError
+			e.SetExtra(ErrorExtraWeatherstackCode, strconv.Itoa(int(errorResponse.Error.Code)))
+			e.SetExtra(ErrorExtraWeatherstackType, errorResponse.Error.Type)
+		}
+
+		if response != nil && response.StatusCode == http.StatusTooManyRequests {
+			rateLimitedError := RateLimitedError{RetryAfter: retryAfter(response)}
+			e.SetMessage(&rateLimitedError)
+			requestError.Err = &rateLimitedError
+			e.SetExtra(ErrorExtraRetryAfter, strconv.Itoa(int(rateLimitedError.RetryAfter.Seconds())))
+		}
+
+		if errorResponse.Error.Type == ErrorTypeHTTPSAccessRestricted {
+			e.SetMessagef("%s, set ServiceConfig.Scheme to \"http\" for this plan", errorResponse.Error.Error())
+		}
+
+		return request, response, nil, requestError
+	}
+
+	return request, response, rawResponse, nil
+}
+
+// decodeResponse unmarshals a raw response body into responseModel
+func (service *Service) decodeResponse(rawResponse []byte, responseModel interface{}, meta *ResponseMeta) *errortools.Error {
+	if responseModel == nil {
+		return nil
+	}
+
+	err := json.Unmarshal(rawResponse, responseModel)
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	e := service.checkStrict(rawResponse, responseModel)
+	if e != nil {
+		return e
+	}
+
+	if setter, ok := responseModel.(rawResponseSetter); ok && service.includeRaw {
+		setter.setRawResponse(append([]byte(nil), rawResponse...), meta)
+	}
+
+	if setter, ok := responseModel.(unitsSetter); ok {
+		setter.setUnits()
+	}
+
+	return nil
+}
+
+func (service *Service) unitsOrDefault(units *Units) *Units {
+	if units == nil {
+		return service.units
+	}
+
+	return units
+}
+
+// hourlyOrImplied returns HourlyOn if ServiceConfig.ImplyHourly is set and interval is set without hourly
+func (service *Service) hourlyOrImplied(hourly *Hourly, interval *Interval) *Hourly {
+	if hourly == nil && interval != nil && service.implyHourly {
+		hourlyOn := HourlyOn
+		return &hourlyOn
+	}
+
+	return hourly
+}
 
-	if errorResponse.Error.Info != "" {
-		e.SetMessage(errorResponse.Error.Info)
+func (service *Service) languageOrDefault(language *Language) *Language {
+	if language == nil {
+		return service.language
 	}
 
-	return request, response, e
+	return language
+}
+
+// setAccessKey adds the access key to query, unless ServiceConfig.OmitAccessKey is set
+func (service *Service) setAccessKey(query url.Values) {
+	if !service.omitKey {
+		query.Set("access_key", service.accessKey)
+	}
 }
 
 func (service *Service) url(path string) string {
-	return fmt.Sprintf("%s/%s", apiURL, path)
+	return fmt.Sprintf("%s/%s", service.baseURL, path)
+}
+
+// urlWithOverride returns the url for path, using baseURLOverride instead of the default base url if set
+func (service *Service) urlWithOverride(baseURLOverride *string, path string) (string, *errortools.Error) {
+	if baseURLOverride == nil {
+		return service.url(path), nil
+	}
+
+	baseURL, err := url.Parse(*baseURLOverride)
+	if err != nil {
+		return "", errortools.ErrorMessagef("Invalid BaseURLOverride: %s", err.Error())
+	}
+
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return "", errortools.ErrorMessagef("Invalid BaseURLOverride: %s", *baseURLOverride)
+	}
+
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(baseURL.String(), "/"), path), nil
 }
 
-func (service *Service) get(requestConfig *go_http.RequestConfig) (*http.Request, *http.Response, *errortools.Error) {
-	return service.httpRequest(http.MethodGet, requestConfig)
+func (service *Service) get(ctx context.Context, requestConfig *go_http.RequestConfig) (*http.Request, *http.Response, *RequestError) {
+	return service.httpRequest(ctx, http.MethodGet, requestConfig)
 }
 
 func (service *Service) APIName() string {
@@ -86,9 +514,9 @@ func (service *Service) APIKey() string {
 }
 
 func (service *Service) APICallCount() int64 {
-	return service.httpService.RequestCount()
+	return atomic.LoadInt64(&service.requestCount)
 }
 
 func (service *Service) APIReset() {
-	service.httpService.ResetRequestCount()
+	atomic.StoreInt64(&service.requestCount, 0)
 }
@@ -0,0 +1,203 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+// StrictConfig enables comparing each response to the fields of its model, detecting changes of the Weatherstack schema
+type StrictConfig struct {
+	OnDrift     func(drift SchemaDrift) // called for each response deviating from its model
+	FailOnDrift bool                    // return an error for such responses instead of the decoded response
+}
+
+// SchemaDrift lists the deviations of a response from its model as paths such as "historical.*.hourly[].windgust",
+// "*" standing for any key of an object decoded into a map and "[]" for any element of an array.
+// Fields that may be absent (pointers, slices, maps, NullFloat64OrString, NullInt64OrString and fields tagged omitempty)
+// are never reported as missing.
+type SchemaDrift struct {
+	Model   string   // type of the response model, e.g. "*weatherstack.HistoricalResponse"
+	Unknown []string // keys in the response without a field in the model
+	Missing []string // fields of the model not in the response
+}
+
+func (drift SchemaDrift) isEmpty() bool {
+	return len(drift.Unknown) == 0 && len(drift.Missing) == 0
+}
+
+func (drift SchemaDrift) String() string {
+	parts := []string{}
+	if len(drift.Unknown) > 0 {
+		parts = append(parts, fmt.Sprintf("unknown fields %s", strings.Join(drift.Unknown, ", ")))
+	}
+	if len(drift.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing fields %s", strings.Join(drift.Missing, ", ")))
+	}
+
+	return fmt.Sprintf("%s: %s", drift.Model, strings.Join(parts, "; "))
+}
+
+// checkStrict compares rawResponse to responseModel if strict mode is enabled
+func (service *Service) checkStrict(rawResponse []byte, responseModel interface{}) *errortools.Error {
+	if service.strict == nil {
+		return nil
+	}
+
+	var value interface{}
+	err := json.Unmarshal(rawResponse, &value)
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	unknown := map[string]bool{}
+	missing := map[string]bool{}
+	compareSchema(value, reflect.TypeOf(responseModel), "", unknown, missing)
+
+	drift := SchemaDrift{
+		Model:   fmt.Sprintf("%T", responseModel),
+		Unknown: sortedKeys(unknown),
+		Missing: sortedKeys(missing),
+	}
+	if drift.isEmpty() {
+		return nil
+	}
+
+	if service.strict.OnDrift != nil {
+		service.strict.OnDrift(drift)
+	}
+
+	if service.strict.FailOnDrift {
+		return errortools.ErrorMessagef("Response deviates from schema, %s", drift.String())
+	}
+
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// compareSchema adds the paths of the keys of value without field in t to unknown,
+// and the paths of the required fields of t without key in value to missing
+func compareSchema(value interface{}, t reflect.Type, path string, unknown map[string]bool, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// types decoding themselves, such as the w_types types, are compared as a whole
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		fields := jsonFields(t)
+		for key, fieldValue := range object {
+			field, ok := fields[key]
+			if !ok {
+				unknown[joinPath(path, key)] = true
+				continue
+			}
+			compareSchema(fieldValue, field.Type, joinPath(path, key), unknown, missing)
+		}
+
+		for key, field := range fields {
+			if _, ok := object[key]; !ok && isRequiredField(field) {
+				missing[joinPath(path, key)] = true
+			}
+		}
+	case reflect.Map:
+		if object, ok := value.(map[string]interface{}); ok {
+			for _, elementValue := range object {
+				compareSchema(elementValue, t.Elem(), joinPath(path, "*"), unknown, missing)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if array, ok := value.([]interface{}); ok {
+			for _, elementValue := range array {
+				compareSchema(elementValue, t.Elem(), path+"[]", unknown, missing)
+			}
+		}
+	}
+}
+
+// jsonFields returns the fields of struct type t keyed by their JSON name, including those of embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedField := range jsonFields(field.Type) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedField
+				}
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+
+	return fields
+}
+
+// optionalTypes are the types of fields that Weatherstack only returns for some plans, units or locations
+var optionalTypes = map[reflect.Type]bool{
+	reflect.TypeOf(w_types.NullFloat64OrString{}): true,
+	reflect.TypeOf(w_types.NullInt64OrString{}):   true,
+}
+
+// isRequiredField reports whether the field is expected in each response
+func isRequiredField(field reflect.StructField) bool {
+	if strings.Contains(field.Tag.Get("json"), ",omitempty") {
+		return false
+	}
+
+	switch field.Type.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return false
+	}
+
+	return !optionalTypes[field.Type]
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,35 @@
+package weatherstack
+
+import (
+	"reflect"
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+// NullableName has the prefix by which optional types used to be recognized
+type NullableName string
+
+func TestCompareSchemaMissingFields(t *testing.T) {
+	type model struct {
+		Name        string                      `json:"name"`
+		Optional    string                      `json:"optional,omitempty"`
+		Gust        w_types.NullFloat64OrString `json:"gust"`
+		Chance      w_types.NullInt64OrString   `json:"chance"`
+		Nullable    NullableName                `json:"nullable"`
+		Pointer     *string                     `json:"pointer"`
+		Hourly      []HourlyWeather             `json:"hourly"`
+		Temperature w_types.Float64OrString     `json:"temperature"`
+	}
+
+	unknown := map[string]bool{}
+	missing := map[string]bool{}
+	compareSchema(map[string]interface{}{"extra": 1}, reflect.TypeOf(model{}), "", unknown, missing)
+
+	if want := []string{"name", "nullable", "temperature"}; !reflect.DeepEqual(sortedKeys(missing), want) {
+		t.Errorf("missing = %v, want %v", sortedKeys(missing), want)
+	}
+	if want := []string{"extra"}; !reflect.DeepEqual(sortedKeys(unknown), want) {
+		t.Errorf("unknown = %v, want %v", sortedKeys(unknown), want)
+	}
+}
@@ -0,0 +1,82 @@
+package weatherstack
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tracer is an optional hook invoked around each request, e.g. to bridge to OpenTelemetry
+type Tracer interface {
+	StartSpan(name string) Span
+}
+
+// ContextTracer is a Tracer that derives spans from the caller's context, so they join the caller's trace.
+// The returned context is used for the request, e.g. for trace header propagation by an instrumented transport.
+type ContextTracer interface {
+	Tracer
+	StartSpanContext(ctx context.Context, name string) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttribute(key string, value interface{})
+	End()
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) End()                                       {}
+
+// startSpan starts a span for a request to _url, which must not contain the access key yet
+func (service *Service) startSpan(ctx context.Context, _url *url.URL) (context.Context, Span) {
+	if service.tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	endpoint := strings.TrimPrefix(_url.Path, "/")
+	name := "weatherstack." + endpoint
+
+	var span Span
+	if tracer, ok := service.tracer.(ContextTracer); ok {
+		ctx, span = tracer.StartSpanContext(ctx, name)
+	} else {
+		span = service.tracer.StartSpan(name)
+	}
+
+	values := _url.Query()
+
+	span.SetAttribute("weatherstack.endpoint", endpoint)
+	span.SetAttribute("weatherstack.url", _url.String())
+	span.SetAttribute("weatherstack.query", values.Get("query"))
+	span.SetAttribute("weatherstack.units", values.Get("units"))
+
+	if date := values.Get("historical_date"); date != "" {
+		span.SetAttribute("weatherstack.historical_date", date)
+	}
+	if start := values.Get("historical_date_start"); start != "" {
+		span.SetAttribute("weatherstack.historical_date_start", start)
+		span.SetAttribute("weatherstack.historical_date_end", values.Get("historical_date_end"))
+	}
+
+	return ctx, span
+}
+
+func endSpan(span Span, response *http.Response, rawResponse []byte, apiError *WeatherstackError, e *RequestError) {
+	if response != nil {
+		span.SetAttribute("http.status_code", response.StatusCode)
+	}
+
+	span.SetAttribute("weatherstack.response_size", len(rawResponse))
+
+	if apiError != nil {
+		span.SetAttribute("weatherstack.error_code", int(apiError.Code))
+	}
+
+	if e != nil {
+		span.SetAttribute("error", e.Message())
+	}
+
+	span.End()
+}
@@ -0,0 +1,54 @@
+package weatherstack
+
+import "time"
+
+// SkinType is the Fitzpatrick skin type
+type SkinType int
+
+const (
+	SkinTypeI   SkinType = 1 // always burns, never tans
+	SkinTypeII  SkinType = 2 // usually burns, tans minimally
+	SkinTypeIII SkinType = 3 // sometimes burns, tans uniformly
+	SkinTypeIV  SkinType = 4 // burns minimally, always tans well
+	SkinTypeV   SkinType = 5 // very rarely burns, tans very easily
+	SkinTypeVI  SkinType = 6 // never burns
+)
+
+// minimal erythemal dose (J/m²) per skin type
+var minimalErythemalDoses = map[SkinType]float64{
+	SkinTypeI:   200,
+	SkinTypeII:  250,
+	SkinTypeIII: 300,
+	SkinTypeIV:  450,
+	SkinTypeV:   600,
+	SkinTypeVI:  1000,
+}
+
+// erythemal irradiance (W/m²) per UV index unit
+const uvIndexIrradiance float64 = 0.025
+
+// UVSafeExposure estimates the time before sunburn per the minimal erythemal dose (MED) model:
+// the time it takes for the erythemal irradiance (UV index * 0.025 W/m²) to add up to the MED of the skin type.
+// 0 is returned for a UV index of 0 or below (no risk of sunburn) or an unknown skin type.
+func UVSafeExposure(index int, skinType SkinType) time.Duration {
+	med, ok := minimalErythemalDoses[skinType]
+	if !ok || index <= 0 {
+		return 0
+	}
+
+	seconds := med / (float64(index) * uvIndexIrradiance)
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (c CurrentWeather) UVSafeExposure(skinType SkinType) time.Duration {
+	return UVSafeExposure(int(c.UVIndex), skinType)
+}
+
+func (w Weather) UVSafeExposure(skinType SkinType) time.Duration {
+	return UVSafeExposure(int(w.UVIndex), skinType)
+}
+
+func (h HourlyWeather) UVSafeExposure(skinType SkinType) time.Duration {
+	return UVSafeExposure(int(h.UVIndex.Value()), skinType)
+}
@@ -0,0 +1,182 @@
+package weatherstack
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+const usageMonthFormat string = "2006-01"
+
+// ErrUsageBudgetExceeded is the error with which requests are refused once UsageConfig.MonthlyBudget is reached
+var ErrUsageBudgetExceeded = errors.New("monthly usage budget exceeded")
+
+// UsageStore stores the number of successful requests per calendar month ("2006-01", UTC) and endpoint.
+// Implementations must be safe for concurrent use, a shared store (e.g. a database) tracks usage across processes.
+type UsageStore interface {
+	Increment(month string, endpoint string) error
+	Counts(month string) (map[string]int64, error)
+}
+
+type UsageConfig struct {
+	Store            UsageStore  // defaults to a new MemoryUsageStore
+	MonthlyBudget    *int64      // number of API calls of the plan per month
+	WarnRatio        *float64    // invoke OnWarn once this fraction of MonthlyBudget is used, e.g. 0.9
+	OnWarn           func(Usage) // invoked for each request that succeeds once WarnRatio is reached
+	RefuseOverBudget bool        // refuse requests with ErrUsageBudgetExceeded once MonthlyBudget is used
+}
+
+// Usage holds the successful requests of a calendar month
+type Usage struct {
+	Month      string // "2006-01", UTC
+	Total      int64
+	ByEndpoint map[string]int64
+	Budget     *int64
+}
+
+// Remaining returns the number of requests left within the budget, nil if no budget is set
+func (u Usage) Remaining() *int64 {
+	if u.Budget == nil {
+		return nil
+	}
+
+	remaining := *u.Budget - u.Total
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &remaining
+}
+
+// MemoryUsageStore is an in-memory UsageStore, usage is lost when the process exits
+type MemoryUsageStore struct {
+	mutex  sync.Mutex
+	counts map[string]map[string]int64
+}
+
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{
+		counts: make(map[string]map[string]int64),
+	}
+}
+
+func (store *MemoryUsageStore) Increment(month string, endpoint string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.counts[month] == nil {
+		store.counts[month] = make(map[string]int64)
+	}
+	store.counts[month][endpoint]++
+
+	return nil
+}
+
+func (store *MemoryUsageStore) Counts(month string) (map[string]int64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	counts := make(map[string]int64, len(store.counts[month]))
+	for endpoint, count := range store.counts[month] {
+		counts[endpoint] = count
+	}
+
+	return counts, nil
+}
+
+// usageTracker tracks usage client-side, Weatherstack having no usage endpoint.
+// The budget check is best-effort: concurrent requests may slightly exceed it.
+type usageTracker struct {
+	config UsageConfig
+}
+
+func newUsageTracker(config *UsageConfig) *usageTracker {
+	if config == nil {
+		return nil
+	}
+
+	tracker := usageTracker{
+		config: *config,
+	}
+	if tracker.config.Store == nil {
+		tracker.config.Store = NewMemoryUsageStore()
+	}
+
+	return &tracker
+}
+
+func (tracker *usageTracker) usage(month string) (*Usage, *errortools.Error) {
+	counts, err := tracker.config.Store.Counts(month)
+	if err != nil {
+		return nil, errortools.ErrorMessage(err)
+	}
+
+	usage := Usage{
+		Month:      month,
+		ByEndpoint: counts,
+		Budget:     tracker.config.MonthlyBudget,
+	}
+	for _, count := range counts {
+		usage.Total += count
+	}
+
+	return &usage, nil
+}
+
+// allow returns an error if the request must be refused because the budget is used
+func (tracker *usageTracker) allow() *RequestError {
+	if tracker == nil || !tracker.config.RefuseOverBudget || tracker.config.MonthlyBudget == nil {
+		return nil
+	}
+
+	usage, e := tracker.usage(time.Now().UTC().Format(usageMonthFormat))
+	if e != nil {
+		return newRequestError(e, nil)
+	}
+
+	if usage.Total >= *tracker.config.MonthlyBudget {
+		return requestErrorOf(ErrUsageBudgetExceeded)
+	}
+
+	return nil
+}
+
+// record counts a successful request to _url, errors of the store are ignored so they do not fail the request
+func (tracker *usageTracker) record(_url *url.URL) {
+	if tracker == nil {
+		return
+	}
+
+	month := time.Now().UTC().Format(usageMonthFormat)
+
+	err := tracker.config.Store.Increment(month, strings.TrimPrefix(_url.Path, "/"))
+	if err != nil {
+		return
+	}
+
+	if tracker.config.OnWarn == nil || tracker.config.WarnRatio == nil || tracker.config.MonthlyBudget == nil {
+		return
+	}
+
+	usage, e := tracker.usage(month)
+	if e != nil {
+		return
+	}
+
+	if float64(usage.Total) >= *tracker.config.WarnRatio*float64(*tracker.config.MonthlyBudget) {
+		tracker.config.OnWarn(*usage)
+	}
+}
+
+// Usage returns the successful requests of the current calendar month (UTC), as tracked by ServiceConfig.Usage
+func (service *Service) Usage() (*Usage, *errortools.Error) {
+	if service.usage == nil {
+		return nil, errortools.ErrorMessage("Usage tracking not enabled, set ServiceConfig.Usage")
+	}
+
+	return service.usage.usage(time.Now().UTC().Format(usageMonthFormat))
+}
@@ -0,0 +1,61 @@
+package weatherstack
+
+import (
+	"strings"
+	"time"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// violations collects the constraint violations of a config, so Validate can report them all at once
+type violations []string
+
+func (v *violations) add(e *errortools.Error) {
+	if e != nil {
+		*v = append(*v, strings.TrimSuffix(e.Message(), "."))
+	}
+}
+
+func (v *violations) addf(format string, a ...interface{}) {
+	v.add(errortools.ErrorMessagef(format, a...))
+}
+
+func (v violations) error() *errortools.Error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return errortools.ErrorMessage(strings.Join(v, "; "))
+}
+
+// validateQueryOrCoordinates requires exactly one of query and coordinates to be set, and valid
+func validateQueryOrCoordinates(query string, coordinates *Coordinates) *errortools.Error {
+	if query == "" && coordinates == nil {
+		return errortools.ErrorMessage("Query or Coordinates must be set")
+	}
+
+	_, e := resolveQuery(query, coordinates)
+
+	return e
+}
+
+// validateHourlyInterval rejects an Interval unless hourly records are enabled, Weatherstack ignoring it otherwise
+func validateHourlyInterval(hourly *Hourly, interval *Interval) *errortools.Error {
+	if hourly != nil && *hourly != HourlyOn && *hourly != HourlyOff {
+		return errortools.ErrorMessagef("Invalid Hourly: %v", int64(*hourly))
+	}
+
+	if interval != nil && (hourly == nil || *hourly != HourlyOn) {
+		return errortools.ErrorMessage("Interval requires Hourly to be HourlyOn")
+	}
+
+	return nil
+}
+
+func validateTimeout(timeout *time.Duration) *errortools.Error {
+	if timeout != nil && *timeout <= 0 {
+		return errortools.ErrorMessage("Timeout must be positive")
+	}
+
+	return nil
+}
@@ -1,60 +1,74 @@
 package weatherstack
 
 import (
+	"cloud.google.com/go/civil"
 	go_types "github.com/leapforce-libraries/go_types"
 	w_types "github.com/leapforce-libraries/go_weatherstack/types"
 )
 
 type Weather struct {
-	Date      w_types.DateString `json:"date"`
-	DateEpoch int64              `json:"date_epoch"`
-	Astro     Astro              `json:"astro"`
-	MinTemp   int64              `json:"mintemp"`
-	MaxTemp   int64              `json:"maxtemp"`
-	AvgTemp   int64              `json:"avgtemp"`
-	TotalSnow float64            `json:"totalsnow"`
-	SunHour   float64            `json:"sunhour"`
-	UVIndex   int64              `json:"uv_index"`
-	Hourly    []HourlyWeather    `json:"hourly"`
+	Date      w_types.DateString      `json:"date"`
+	DateEpoch int64                   `json:"date_epoch"`
+	Astro     Astro                   `json:"astro"`
+	MinTemp   w_types.Float64OrString `json:"mintemp"`
+	MaxTemp   w_types.Float64OrString `json:"maxtemp"`
+	AvgTemp   w_types.Float64OrString `json:"avgtemp"`
+	TotalSnow w_types.Float64OrString `json:"totalsnow"`
+	SunHour   w_types.Float64OrString `json:"sunhour"`
+	UVIndex   w_types.Int64OrString   `json:"uv_index"`
+	Hourly    []HourlyWeather         `json:"hourly"`
+	units     Units                   // units of the request, set when decoding the response
+}
+
+// HistoricalWeather is the weather of a single day as returned by the historical endpoint
+type HistoricalWeather = Weather
+
+// CivilDate returns Date as a civil.Date
+func (w Weather) CivilDate() civil.Date {
+	return civil.DateOf(w.Date.Value())
 }
 
 type Astro struct {
-	Sunrise          w_types.TimeStruct `json:"sunrise"`
-	Sunset           w_types.TimeStruct `json:"sunset"`
-	Moonrise         w_types.TimeStruct `json:"moonrise"`
-	Moonset          w_types.TimeStruct `json:"moonset"`
-	MoonPhase        string             `json:"moon_phase"`
-	MoonIllumination int64              `json:"moon_illumination"`
+	Sunrise          w_types.TimeStruct    `json:"sunrise"`
+	Sunset           w_types.TimeStruct    `json:"sunset"`
+	Moonrise         w_types.TimeStruct    `json:"moonrise"`
+	Moonset          w_types.TimeStruct    `json:"moonset"`
+	MoonPhase        string                `json:"moon_phase"`
+	MoonIllumination w_types.Int64OrString `json:"moon_illumination"`
 }
 
+// HourlyWeather is an hourly record. Heatindex, Dewpoint, Windchill, Windgust, the ChanceOf fields and UVIndex
+// may be absent depending on the endpoint, plan and interval, their Valid field tells an absent value from zero.
 type HourlyWeather struct {
-	Time                go_types.Int64String `json:"time"`
-	Temperature         int64                `json:"temperature"`
-	WindSpeed           int64                `json:"wind_speed"`
-	WindDegree          int64                `json:"wind_degree"`
-	WindDir             string               `json:"wind_dir"`
-	WeatherCode         int64                `json:"weather_code"`
-	WeatherIcons        []string             `json:"weather_icons"`
-	WeatherDescriptions []string             `json:"weather_descriptions"`
-	Precip              float64              `json:"precip"`
-	Humidity            int64                `json:"humidity"`
-	Visibility          int64                `json:"visibility"`
-	Pressure            int64                `json:"pressure"`
-	Cloudcover          int64                `json:"cloudcover"`
-	Heatindex           int64                `json:"heatindex"`
-	Dewpoint            int64                `json:"dewpoint"`
-	Windchill           int64                `json:"windchill"`
-	Windgust            int64                `json:"windgust"`
-	FeelsLike           int64                `json:"feelslike"`
-	ChanceOfRain        int64                `json:"chanceofrain"`
-	ChanceOfRemDry      int64                `json:"chanceofremdry"`
-	ChanceOfWindy       int64                `json:"chanceofwindy"`
-	ChanceOfOvercast    int64                `json:"chanceofovercast"`
-	ChanceOfSunshine    int64                `json:"chanceofsunshine"`
-	ChanceOfFrost       int64                `json:"chanceoffrost"`
-	ChanceOfHighTemp    int64                `json:"chanceofhightemp"`
-	ChanceOfFog         int64                `json:"chanceoffog"`
-	ChanceOfSnow        int64                `json:"chanceofsnow"`
-	ChanceOfThunder     int64                `json:"chanceofthunder"`
-	UVIndex             int64                `json:"uv_index"`
+	Time                go_types.Int64String        `json:"time"`
+	Temperature         w_types.Float64OrString     `json:"temperature"`
+	WindSpeed           w_types.Float64OrString     `json:"wind_speed"`
+	WindDegree          w_types.Int64OrString       `json:"wind_degree"`
+	WindDir             string                      `json:"wind_dir"`
+	WeatherCode         WeatherCode                 `json:"weather_code"`
+	WeatherIcons        []string                    `json:"weather_icons"`
+	WeatherDescriptions []string                    `json:"weather_descriptions"`
+	Precip              w_types.Float64OrString     `json:"precip"`
+	Humidity            w_types.Int64OrString       `json:"humidity"`
+	Visibility          w_types.Float64OrString     `json:"visibility"`
+	Pressure            w_types.Float64OrString     `json:"pressure"`
+	Cloudcover          w_types.Int64OrString       `json:"cloudcover"`
+	Heatindex           w_types.NullFloat64OrString `json:"heatindex"`
+	Dewpoint            w_types.NullFloat64OrString `json:"dewpoint"`
+	Windchill           w_types.NullFloat64OrString `json:"windchill"`
+	Windgust            w_types.NullFloat64OrString `json:"windgust"`
+	FeelsLike           w_types.Float64OrString     `json:"feelslike"`
+	ChanceOfRain        w_types.NullInt64OrString   `json:"chanceofrain"`
+	ChanceOfRemDry      w_types.NullInt64OrString   `json:"chanceofremdry"`
+	ChanceOfWindy       w_types.NullInt64OrString   `json:"chanceofwindy"`
+	ChanceOfOvercast    w_types.NullInt64OrString   `json:"chanceofovercast"`
+	ChanceOfSunshine    w_types.NullInt64OrString   `json:"chanceofsunshine"`
+	ChanceOfFrost       w_types.NullInt64OrString   `json:"chanceoffrost"`
+	ChanceOfHighTemp    w_types.NullInt64OrString   `json:"chanceofhightemp"`
+	ChanceOfFog         w_types.NullInt64OrString   `json:"chanceoffog"`
+	ChanceOfSnow        w_types.NullInt64OrString   `json:"chanceofsnow"`
+	ChanceOfThunder     w_types.NullInt64OrString   `json:"chanceofthunder"`
+	UVIndex             w_types.NullInt64OrString   `json:"uv_index"`
+	Interpolated        bool                        `json:"interpolated,omitempty"` // inserted by FillGaps, not returned by Weatherstack
+	units               Units                       // units of the request, set when decoding the response
 }
@@ -0,0 +1,213 @@
+package weatherstack
+
+// WeatherCode is the numeric weather condition code returned by Weatherstack
+type WeatherCode int64
+
+const (
+	WeatherCodeSunny                              WeatherCode = 113
+	WeatherCodePartlyCloudy                       WeatherCode = 116
+	WeatherCodeCloudy                             WeatherCode = 119
+	WeatherCodeOvercast                           WeatherCode = 122
+	WeatherCodeMist                               WeatherCode = 143
+	WeatherCodePatchyRainPossible                 WeatherCode = 176
+	WeatherCodePatchySnowPossible                 WeatherCode = 179
+	WeatherCodePatchySleetPossible                WeatherCode = 182
+	WeatherCodePatchyFreezingDrizzlePossible      WeatherCode = 185
+	WeatherCodeThunderyOutbreaksPossible          WeatherCode = 200
+	WeatherCodeBlowingSnow                        WeatherCode = 227
+	WeatherCodeBlizzard                           WeatherCode = 230
+	WeatherCodeFog                                WeatherCode = 248
+	WeatherCodeFreezingFog                        WeatherCode = 260
+	WeatherCodePatchyLightDrizzle                 WeatherCode = 263
+	WeatherCodeLightDrizzle                       WeatherCode = 266
+	WeatherCodeFreezingDrizzle                    WeatherCode = 281
+	WeatherCodeHeavyFreezingDrizzle               WeatherCode = 284
+	WeatherCodePatchyLightRain                    WeatherCode = 293
+	WeatherCodeLightRain                          WeatherCode = 296
+	WeatherCodeModerateRainAtTimes                WeatherCode = 299
+	WeatherCodeModerateRain                       WeatherCode = 302
+	WeatherCodeHeavyRainAtTimes                   WeatherCode = 305
+	WeatherCodeHeavyRain                          WeatherCode = 308
+	WeatherCodeLightFreezingRain                  WeatherCode = 311
+	WeatherCodeModerateOrHeavyFreezingRain        WeatherCode = 314
+	WeatherCodeLightSleet                         WeatherCode = 317
+	WeatherCodeModerateOrHeavySleet               WeatherCode = 320
+	WeatherCodePatchyLightSnow                    WeatherCode = 323
+	WeatherCodeLightSnow                          WeatherCode = 326
+	WeatherCodePatchyModerateSnow                 WeatherCode = 329
+	WeatherCodeModerateSnow                       WeatherCode = 332
+	WeatherCodePatchyHeavySnow                    WeatherCode = 335
+	WeatherCodeHeavySnow                          WeatherCode = 338
+	WeatherCodeIcePellets                         WeatherCode = 350
+	WeatherCodeLightRainShower                    WeatherCode = 353
+	WeatherCodeModerateOrHeavyRainShower          WeatherCode = 356
+	WeatherCodeTorrentialRainShower               WeatherCode = 359
+	WeatherCodeLightSleetShowers                  WeatherCode = 362
+	WeatherCodeModerateOrHeavySleetShowers        WeatherCode = 365
+	WeatherCodeLightSnowShowers                   WeatherCode = 368
+	WeatherCodeModerateOrHeavySnowShowers         WeatherCode = 371
+	WeatherCodeLightShowersOfIcePellets           WeatherCode = 374
+	WeatherCodeModerateOrHeavyShowersOfIcePellets WeatherCode = 377
+	WeatherCodePatchyLightRainWithThunder         WeatherCode = 386
+	WeatherCodeModerateOrHeavyRainWithThunder     WeatherCode = 389
+	WeatherCodePatchyLightSnowWithThunder         WeatherCode = 392
+	WeatherCodeModerateOrHeavySnowWithThunder     WeatherCode = 395
+)
+
+var weatherCodeDescriptions = map[WeatherCode]string{
+	WeatherCodeSunny:                              "Sunny",
+	WeatherCodePartlyCloudy:                       "Partly cloudy",
+	WeatherCodeCloudy:                             "Cloudy",
+	WeatherCodeOvercast:                           "Overcast",
+	WeatherCodeMist:                               "Mist",
+	WeatherCodePatchyRainPossible:                 "Patchy rain possible",
+	WeatherCodePatchySnowPossible:                 "Patchy snow possible",
+	WeatherCodePatchySleetPossible:                "Patchy sleet possible",
+	WeatherCodePatchyFreezingDrizzlePossible:      "Patchy freezing drizzle possible",
+	WeatherCodeThunderyOutbreaksPossible:          "Thundery outbreaks possible",
+	WeatherCodeBlowingSnow:                        "Blowing snow",
+	WeatherCodeBlizzard:                           "Blizzard",
+	WeatherCodeFog:                                "Fog",
+	WeatherCodeFreezingFog:                        "Freezing fog",
+	WeatherCodePatchyLightDrizzle:                 "Patchy light drizzle",
+	WeatherCodeLightDrizzle:                       "Light drizzle",
+	WeatherCodeFreezingDrizzle:                    "Freezing drizzle",
+	WeatherCodeHeavyFreezingDrizzle:               "Heavy freezing drizzle",
+	WeatherCodePatchyLightRain:                    "Patchy light rain",
+	WeatherCodeLightRain:                          "Light rain",
+	WeatherCodeModerateRainAtTimes:                "Moderate rain at times",
+	WeatherCodeModerateRain:                       "Moderate rain",
+	WeatherCodeHeavyRainAtTimes:                   "Heavy rain at times",
+	WeatherCodeHeavyRain:                          "Heavy rain",
+	WeatherCodeLightFreezingRain:                  "Light freezing rain",
+	WeatherCodeModerateOrHeavyFreezingRain:        "Moderate or heavy freezing rain",
+	WeatherCodeLightSleet:                         "Light sleet",
+	WeatherCodeModerateOrHeavySleet:               "Moderate or heavy sleet",
+	WeatherCodePatchyLightSnow:                    "Patchy light snow",
+	WeatherCodeLightSnow:                          "Light snow",
+	WeatherCodePatchyModerateSnow:                 "Patchy moderate snow",
+	WeatherCodeModerateSnow:                       "Moderate snow",
+	WeatherCodePatchyHeavySnow:                    "Patchy heavy snow",
+	WeatherCodeHeavySnow:                          "Heavy snow",
+	WeatherCodeIcePellets:                         "Ice pellets",
+	WeatherCodeLightRainShower:                    "Light rain shower",
+	WeatherCodeModerateOrHeavyRainShower:          "Moderate or heavy rain shower",
+	WeatherCodeTorrentialRainShower:               "Torrential rain shower",
+	WeatherCodeLightSleetShowers:                  "Light sleet showers",
+	WeatherCodeModerateOrHeavySleetShowers:        "Moderate or heavy sleet showers",
+	WeatherCodeLightSnowShowers:                   "Light snow showers",
+	WeatherCodeModerateOrHeavySnowShowers:         "Moderate or heavy snow showers",
+	WeatherCodeLightShowersOfIcePellets:           "Light showers of ice pellets",
+	WeatherCodeModerateOrHeavyShowersOfIcePellets: "Moderate or heavy showers of ice pellets",
+	WeatherCodePatchyLightRainWithThunder:         "Patchy light rain with thunder",
+	WeatherCodeModerateOrHeavyRainWithThunder:     "Moderate or heavy rain with thunder",
+	WeatherCodePatchyLightSnowWithThunder:         "Patchy light snow with thunder",
+	WeatherCodeModerateOrHeavySnowWithThunder:     "Moderate or heavy snow with thunder",
+}
+
+// Description returns the English description of the code, independent of the requested language.
+// An empty string is returned for unknown codes.
+func (code WeatherCode) Description() string {
+	return weatherCodeDescriptions[code]
+}
+
+// IsPrecipitation reports whether the code denotes rain, drizzle, sleet, snow or ice pellets (including "possible" codes)
+func (code WeatherCode) IsPrecipitation() bool {
+	switch code {
+	case WeatherCodePatchyRainPossible, WeatherCodePatchySnowPossible, WeatherCodePatchySleetPossible,
+		WeatherCodePatchyFreezingDrizzlePossible, WeatherCodeBlizzard:
+		return true
+	}
+
+	return code >= WeatherCodePatchyLightDrizzle && code <= WeatherCodeModerateOrHeavySnowWithThunder
+}
+
+// Severity ranks weather codes from no significant weather to severe weather
+type Severity int
+
+const (
+	SeverityNone     Severity = iota // clear or cloudy
+	SeverityLow                      // mist, patchy or light precipitation
+	SeverityModerate                 // fog, moderate precipitation
+	SeverityHigh                     // heavy precipitation, freezing rain, blowing snow
+	SeveritySevere                   // thunder, blizzard, torrential rain
+)
+
+// Severity returns the severity of the code, SeverityNone for unknown codes
+func (code WeatherCode) Severity() Severity {
+	switch code {
+	case WeatherCodeSunny, WeatherCodePartlyCloudy, WeatherCodeCloudy, WeatherCodeOvercast:
+		return SeverityNone
+	case WeatherCodeMist, WeatherCodePatchyRainPossible, WeatherCodePatchySnowPossible, WeatherCodePatchySleetPossible,
+		WeatherCodePatchyFreezingDrizzlePossible, WeatherCodePatchyLightDrizzle, WeatherCodeLightDrizzle,
+		WeatherCodePatchyLightRain, WeatherCodeLightRain, WeatherCodeLightSleet, WeatherCodePatchyLightSnow,
+		WeatherCodeLightSnow, WeatherCodeLightRainShower, WeatherCodeLightSleetShowers, WeatherCodeLightSnowShowers,
+		WeatherCodeLightShowersOfIcePellets:
+		return SeverityLow
+	case WeatherCodeFog, WeatherCodeFreezingFog, WeatherCodeFreezingDrizzle, WeatherCodeModerateRainAtTimes,
+		WeatherCodeModerateRain, WeatherCodeLightFreezingRain, WeatherCodeModerateOrHeavySleet, WeatherCodePatchyModerateSnow,
+		WeatherCodeModerateSnow, WeatherCodeIcePellets, WeatherCodeModerateOrHeavyRainShower,
+		WeatherCodeModerateOrHeavySleetShowers, WeatherCodeModerateOrHeavySnowShowers,
+		WeatherCodeModerateOrHeavyShowersOfIcePellets:
+		return SeverityModerate
+	case WeatherCodeBlowingSnow, WeatherCodeHeavyFreezingDrizzle, WeatherCodeHeavyRainAtTimes, WeatherCodeHeavyRain,
+		WeatherCodeModerateOrHeavyFreezingRain, WeatherCodePatchyHeavySnow, WeatherCodeHeavySnow:
+		return SeverityHigh
+	case WeatherCodeThunderyOutbreaksPossible, WeatherCodeBlizzard, WeatherCodeTorrentialRainShower,
+		WeatherCodePatchyLightRainWithThunder, WeatherCodeModerateOrHeavyRainWithThunder,
+		WeatherCodePatchyLightSnowWithThunder, WeatherCodeModerateOrHeavySnowWithThunder:
+		return SeveritySevere
+	}
+
+	return SeverityNone
+}
+
+// Icon returns a generic icon name for the code, e.g. "clear-day", "rain" or "thunderstorm",
+// isDay selecting between the day and night variant where these differ. An empty string is returned for unknown codes.
+func (code WeatherCode) Icon(isDay bool) string {
+	dayNight := func(icon string) string {
+		if isDay {
+			return icon + "-day"
+		}
+		return icon + "-night"
+	}
+
+	switch code {
+	case WeatherCodeSunny:
+		return dayNight("clear")
+	case WeatherCodePartlyCloudy:
+		return dayNight("partly-cloudy")
+	case WeatherCodeCloudy, WeatherCodeOvercast:
+		return "cloudy"
+	case WeatherCodeMist, WeatherCodeFog, WeatherCodeFreezingFog:
+		return "fog"
+	case WeatherCodePatchyRainPossible, WeatherCodeLightRainShower, WeatherCodeModerateOrHeavyRainShower:
+		return dayNight("showers")
+	case WeatherCodePatchyLightDrizzle, WeatherCodeLightDrizzle, WeatherCodeFreezingDrizzle, WeatherCodeHeavyFreezingDrizzle,
+		WeatherCodePatchyFreezingDrizzlePossible:
+		return "drizzle"
+	case WeatherCodePatchyLightRain, WeatherCodeLightRain, WeatherCodeModerateRainAtTimes, WeatherCodeModerateRain:
+		return "rain"
+	case WeatherCodeHeavyRainAtTimes, WeatherCodeHeavyRain, WeatherCodeTorrentialRainShower:
+		return "heavy-rain"
+	case WeatherCodeLightFreezingRain, WeatherCodeModerateOrHeavyFreezingRain, WeatherCodePatchySleetPossible,
+		WeatherCodeLightSleet, WeatherCodeModerateOrHeavySleet, WeatherCodeLightSleetShowers,
+		WeatherCodeModerateOrHeavySleetShowers:
+		return "sleet"
+	case WeatherCodeIcePellets, WeatherCodeLightShowersOfIcePellets, WeatherCodeModerateOrHeavyShowersOfIcePellets:
+		return "hail"
+	case WeatherCodePatchySnowPossible, WeatherCodePatchyLightSnow, WeatherCodeLightSnow, WeatherCodePatchyModerateSnow,
+		WeatherCodeModerateSnow, WeatherCodeLightSnowShowers:
+		return "snow"
+	case WeatherCodePatchyHeavySnow, WeatherCodeHeavySnow, WeatherCodeModerateOrHeavySnowShowers:
+		return "heavy-snow"
+	case WeatherCodeBlowingSnow, WeatherCodeBlizzard:
+		return "blizzard"
+	case WeatherCodeThunderyOutbreaksPossible, WeatherCodePatchyLightRainWithThunder, WeatherCodeModerateOrHeavyRainWithThunder:
+		return "thunderstorm"
+	case WeatherCodePatchyLightSnowWithThunder, WeatherCodeModerateOrHeavySnowWithThunder:
+		return "thunder-snow"
+	}
+
+	return ""
+}
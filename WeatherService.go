@@ -0,0 +1,22 @@
+package weatherstack
+
+import "context"
+
+// WeatherService covers the endpoint methods of Service, so that code using it can be tested against
+// a fake such as weatherstackmock.Service
+type WeatherService interface {
+	GetCurrentWeather(config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError)
+	GetCurrentWeatherWithContext(ctx context.Context, config GetCurrentWeatherConfig) (*CurrentResponse, *RequestError)
+	GetHistoricalWeather(config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError)
+	GetHistoricalWeatherWithContext(ctx context.Context, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError)
+	GetHistoricalWeatherRange(config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError)
+	GetHistoricalWeatherRangeWithContext(ctx context.Context, config GetHistoricalWeatherConfig) (*HistoricalResponse, *RequestError)
+	GetForecastWeather(config GetForecastWeatherConfig) (*ForecastResponse, *RequestError)
+	GetForecastWeatherWithContext(ctx context.Context, config GetForecastWeatherConfig) (*ForecastResponse, *RequestError)
+	GetMarineWeather(config GetMarineWeatherConfig) (*MarineResponse, *RequestError)
+	GetMarineWeatherWithContext(ctx context.Context, config GetMarineWeatherConfig) (*MarineResponse, *RequestError)
+	Autocomplete(query string) (*AutocompleteResponse, *RequestError)
+	AutocompleteWithContext(ctx context.Context, query string) (*AutocompleteResponse, *RequestError)
+}
+
+var _ WeatherService = (*Service)(nil)
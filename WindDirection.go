@@ -0,0 +1,96 @@
+package weatherstack
+
+import (
+	"math"
+	"strings"
+)
+
+// WindDirection is one of the 16 compass points
+type WindDirection int
+
+const (
+	WindDirectionN WindDirection = iota
+	WindDirectionNNE
+	WindDirectionNE
+	WindDirectionENE
+	WindDirectionE
+	WindDirectionESE
+	WindDirectionSE
+	WindDirectionSSE
+	WindDirectionS
+	WindDirectionSSW
+	WindDirectionSW
+	WindDirectionWSW
+	WindDirectionW
+	WindDirectionWNW
+	WindDirectionNW
+	WindDirectionNNW
+)
+
+var windDirectionNames = [...]string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// WindDirectionFromDegree buckets a degree into the compass point whose 22.5° sector contains it,
+// degrees outside 0-359 are normalized modulo 360
+func WindDirectionFromDegree(degree int) WindDirection {
+	degree = ((degree % 360) + 360) % 360
+
+	return WindDirection(int((float64(degree)+11.25)/22.5) % 16)
+}
+
+// String returns the compass abbreviation used by Weatherstack's wind_dir, e.g. "NNE"
+func (w WindDirection) String() string {
+	if w < 0 || int(w) >= len(windDirectionNames) {
+		return ""
+	}
+
+	return windDirectionNames[w]
+}
+
+func (c CurrentWeather) WindDirection() WindDirection {
+	return WindDirectionFromDegree(int(c.WindDegree))
+}
+
+func (h HourlyWeather) WindDirection() WindDirection {
+	return WindDirectionFromDegree(int(h.WindDegree))
+}
+
+// ParseWindDirection parses a compass abbreviation as in wind_dir (e.g. "SSW"), ok is false if it is not one of the 16 points
+func ParseWindDirection(s string) (WindDirection, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	for i, name := range windDirectionNames {
+		if name == s {
+			return WindDirection(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// Degrees returns the center of the compass point's sector, e.g. 202.5 for SSW
+func (w WindDirection) Degrees() float64 {
+	return float64(w) * 22.5
+}
+
+// MeanWindDegree returns the circular mean of the WindDegree of the hourly records, in [0,360).
+// ok is false if there are no records or the directions cancel each other out (e.g. N and S).
+func MeanWindDegree(hourly []HourlyWeather) (degree float64, ok bool) {
+	var x, y float64
+
+	for _, h := range hourly {
+		radians := float64(h.WindDegree) * math.Pi / 180
+		x += math.Cos(radians)
+		y += math.Sin(radians)
+	}
+
+	if math.Hypot(x, y) < 1e-9 {
+		return 0, false
+	}
+
+	degree = math.Atan2(y, x) * 180 / math.Pi
+	if degree < 0 {
+		degree += 360
+	}
+
+	return degree, true
+}
@@ -0,0 +1,62 @@
+package weatherstack
+
+import "math"
+
+// WindRose buckets all hourly records of the response by wind direction and wind speed,
+// returning per direction the share of records (0-1) in each of the speed bins.
+// The bins are of equal width, from 0 up to the highest wind speed in the response, see WindRoseBinWidth.
+// If bins is not positive or the response has no hourly records an empty wind rose is returned.
+func (r *HistoricalResponse) WindRose(bins int) map[WindDirection][]float64 {
+	windRose := make(map[WindDirection][]float64)
+
+	binWidthKmH := r.WindRoseBinWidth(bins)
+	units := Units(r.Request.Unit)
+	hourlies := r.hourlies()
+
+	if bins <= 0 || len(hourlies) == 0 {
+		return windRose
+	}
+
+	for _, hourly := range hourlies {
+		direction := hourly.WindDirection()
+		if _, ok := windRose[direction]; !ok {
+			windRose[direction] = make([]float64, bins)
+		}
+
+		bin := 0
+		if binWidthKmH > 0 {
+			bin = int(math.Min(float64(bins-1), speedToKmH(hourly.WindSpeed.Value(), units)/binWidthKmH))
+		}
+
+		windRose[direction][bin] += 1 / float64(len(hourlies))
+	}
+
+	return windRose
+}
+
+// WindRoseBinWidth returns the width in km/h of the speed bins of WindRose, 0 if bins is not positive
+// or the response has no hourly records
+func (r *HistoricalResponse) WindRoseBinWidth(bins int) float64 {
+	if bins <= 0 {
+		return 0
+	}
+
+	units := Units(r.Request.Unit)
+	maxSpeed := 0.0
+
+	for _, hourly := range r.hourlies() {
+		maxSpeed = math.Max(maxSpeed, speedToKmH(hourly.WindSpeed.Value(), units))
+	}
+
+	return maxSpeed / float64(bins)
+}
+
+func (r *HistoricalResponse) hourlies() []HourlyWeather {
+	hourlies := []HourlyWeather{}
+
+	for _, day := range r.Historical {
+		hourlies = append(hourlies, day.Hourly...)
+	}
+
+	return hourlies
+}
@@ -0,0 +1,68 @@
+package weatherstack
+
+import (
+	"math"
+	"testing"
+
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func TestWindRose(t *testing.T) {
+	hourly := func(windDegree int64, windSpeed float64) HourlyWeather {
+		return HourlyWeather{WindDegree: w_types.Int64OrString(windDegree), WindSpeed: w_types.Float64OrString(windSpeed)}
+	}
+
+	r := &HistoricalResponse{
+		Request: Request{Unit: string(UnitsMetric)},
+		Historical: map[string]HistoricalWeather{
+			"2021-09-09": {Hourly: []HourlyWeather{hourly(0, 5), hourly(0, 25), hourly(90, 40)}},
+			"2021-09-10": {Hourly: []HourlyWeather{hourly(180, 10)}},
+		},
+	}
+
+	windRose := r.WindRose(4)
+	want := map[WindDirection][]float64{
+		r.Historical["2021-09-09"].Hourly[0].WindDirection(): {0.25, 0, 0.25, 0},
+		r.Historical["2021-09-09"].Hourly[2].WindDirection(): {0, 0, 0, 0.25},
+		r.Historical["2021-09-10"].Hourly[0].WindDirection(): {0, 0.25, 0, 0},
+	}
+
+	if len(windRose) != len(want) {
+		t.Fatalf("WindRose(4) = %v, want %v", windRose, want)
+	}
+	for direction, shares := range want {
+		for bin, share := range shares {
+			if math.Abs(windRose[direction][bin]-share) > 1e-9 {
+				t.Errorf("WindRose(4)[%v] = %v, want %v", direction, windRose[direction], shares)
+				break
+			}
+		}
+	}
+
+	if got := r.WindRoseBinWidth(4); got != 10 {
+		t.Errorf("WindRoseBinWidth(4) = %v, want 10", got)
+	}
+}
+
+func TestWindRoseEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *HistoricalResponse
+		bins int
+	}{
+		{"no records", &HistoricalResponse{}, 4},
+		{"no bins", &HistoricalResponse{Historical: map[string]HistoricalWeather{"2021-09-09": {Hourly: []HourlyWeather{{}}}}}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			windRose := test.r.WindRose(test.bins)
+			if windRose == nil || len(windRose) != 0 {
+				t.Errorf("WindRose(%v) = %v, want an empty wind rose", test.bins, windRose)
+			}
+			if got := test.r.WindRoseBinWidth(test.bins); got != 0 {
+				t.Errorf("WindRoseBinWidth(%v) = %v, want 0", test.bins, got)
+			}
+		})
+	}
+}
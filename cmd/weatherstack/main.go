@@ -0,0 +1,211 @@
+// Command weatherstack requests current, historical or forecast weather from the Weatherstack API.
+//
+// Usage:
+//
+//	weatherstack current -query Amsterdam
+//	weatherstack historical -query Amsterdam -start 2021-09-01 -end 2021-09-07 -format csv
+//	weatherstack forecast -query Amsterdam -days 7 -format json
+//
+// The access key is read from -key or the WEATHERSTACK_ACCESS_KEY environment variable.
+// The table and json formats are in °C, km/h and mm, -units only applies to the csv format.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"cloud.google.com/go/civil"
+	errortools "github.com/leapforce-libraries/go_errortools"
+	weatherstack "github.com/leapforce-libraries/go_weatherstack"
+)
+
+const usage string = `usage: weatherstack <current|historical|forecast> [flags]
+
+Run "weatherstack <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	e := run(os.Args[1], os.Args[2:], os.Stdout)
+	if e != nil {
+		fmt.Fprintln(os.Stderr, e.Message())
+		os.Exit(1)
+	}
+}
+
+func run(command string, args []string, w io.Writer) *errortools.Error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+
+	key := flags.String("key", os.Getenv("WEATHERSTACK_ACCESS_KEY"), "access key, defaults to $WEATHERSTACK_ACCESS_KEY")
+	scheme := flags.String("scheme", "https", `"http" (required by the free plan) or "https"`)
+	query := flags.String("query", "", "location query, e.g. a city, \"lat,lon\" or a zip code")
+	units := flags.String("units", string(weatherstack.UnitsMetric), `"m" (metric), "s" (scientific) or "f" (fahrenheit), format csv only`)
+	format := flags.String("format", "table", `output format: "table", "json" (normalized) or "csv" (historical and forecast only)`)
+
+	var start, end *string
+	var days *int
+	switch command {
+	case "current":
+	case "historical":
+		start = flags.String("start", "", "first date (YYYY-MM-DD)")
+		end = flags.String("end", "", "last date (YYYY-MM-DD), defaults to start")
+	case "forecast":
+		days = flags.Int("days", 0, fmt.Sprintf("number of days (1-%v), defaults to the plan's default", weatherstack.MaxForecastDays))
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags.Parse(args)
+
+	if *format != "table" && *format != "json" && *format != "csv" {
+		return errortools.ErrorMessagef("Invalid format: %s", *format)
+	}
+
+	// table and json print the normalized values, which are always in °C, km/h and mm
+	unitsSet := false
+	flags.Visit(func(f *flag.Flag) {
+		unitsSet = unitsSet || f.Name == "units"
+	})
+	if unitsSet && *format != "csv" {
+		return errortools.ErrorMessagef("Flag -units is only supported for format csv, format %s is in °C, km/h and mm", *format)
+	}
+
+	service, e := weatherstack.NewService(&weatherstack.ServiceConfig{
+		AccessKey: *key,
+		Scheme:    scheme,
+	})
+	if e != nil {
+		return e
+	}
+
+	u := weatherstack.Units(*units)
+
+	var response interface {
+		Normalized() weatherstack.NormalizedResponse
+	}
+	var requestError *weatherstack.RequestError
+
+	switch command {
+	case "current":
+		if *format == "csv" {
+			return errortools.ErrorMessage("Format csv is not supported for current")
+		}
+
+		response, requestError = service.GetCurrentWeather(weatherstack.GetCurrentWeatherConfig{
+			Query: *query,
+			Units: &u,
+		})
+	case "historical":
+		startDate, err := civil.ParseDate(*start)
+		if err != nil {
+			return errortools.ErrorMessagef("Invalid start: %s", *start)
+		}
+
+		config := weatherstack.GetHistoricalWeatherConfig{
+			Query:     *query,
+			StartDate: startDate,
+			Units:     &u,
+		}
+		if *end != "" {
+			endDate, err := civil.ParseDate(*end)
+			if err != nil {
+				return errortools.ErrorMessagef("Invalid end: %s", *end)
+			}
+			config.EndDate = &endDate
+		}
+
+		var historicalResponse *weatherstack.HistoricalResponse
+		historicalResponse, requestError = service.GetHistoricalWeatherRange(config)
+		if requestError == nil && *format == "csv" {
+			return historicalResponse.WriteCSV(w, weatherstack.CSVOptions{})
+		}
+		response = historicalResponse
+	case "forecast":
+		config := weatherstack.GetForecastWeatherConfig{
+			Query: *query,
+			Units: &u,
+		}
+		if *days != 0 {
+			config.ForecastDays = days
+		}
+
+		var forecastResponse *weatherstack.ForecastResponse
+		forecastResponse, requestError = service.GetForecastWeather(config)
+		if requestError == nil && *format == "csv" {
+			return forecastResponse.WriteCSV(w, weatherstack.CSVOptions{})
+		}
+		response = forecastResponse
+	}
+	if requestError != nil {
+		return requestError.ErrorTools()
+	}
+
+	if *format == "json" {
+		return writeJSON(w, response.Normalized())
+	}
+
+	return writeTable(w, response.Normalized())
+}
+
+func writeJSON(w io.Writer, normalized weatherstack.NormalizedResponse) *errortools.Error {
+	b, err := json.MarshalIndent(normalized, "", "  ")
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	_, err = fmt.Fprintln(w, string(b))
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	return nil
+}
+
+// writeTable writes the location followed by the current weather or the days, values in the normalized units
+func writeTable(w io.Writer, normalized weatherstack.NormalizedResponse) *errortools.Error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	location := normalized.Location
+	fmt.Fprintf(tw, "%s, %s, %s (%v,%v)\n\n", location.Name, location.Region, location.Country, location.Lat, location.Lon)
+
+	if current := normalized.Current; current != nil {
+		fmt.Fprintln(tw, "TIME\tTEMP (°C)\tFEELS LIKE (°C)\tWIND (km/h)\tDIR\tPRECIP (mm)\tHUMIDITY (%)\tDESCRIPTION")
+		fmt.Fprintf(tw, "%s\t%v\t%v\t%v\t%s\t%v\t%v\t%s\n",
+			current.Time, current.Temperature, current.FeelsLike, current.WindSpeed, current.WindDir,
+			current.Precipitation, current.Humidity, current.Description)
+	} else {
+		fmt.Fprintln(tw, "DATE\tMIN (°C)\tMAX (°C)\tAVG (°C)\tSNOW (cm)\tSUN (h)\tUV\tSUNRISE\tSUNSET")
+		for _, day := range normalized.Days {
+			fmt.Fprintf(tw, "%s\t%v\t%v\t%v\t%v\t%v\t%v\t%s\t%s\n",
+				day.Date, day.MinTemperature, day.MaxTemperature, day.AvgTemperature, day.TotalSnow,
+				day.SunHours, day.UVIndex, timeOfDay(day.Sunrise), timeOfDay(day.Sunset))
+		}
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	return nil
+}
+
+// timeOfDay returns the hh:mm part of a normalized timestamp
+func timeOfDay(timestamp string) string {
+	i := strings.Index(timestamp, "T")
+	if i < 0 || len(timestamp) < i+6 {
+		return timestamp
+	}
+
+	return timestamp[i+1 : i+6]
+}
@@ -1,5 +1,12 @@
 package weatherstack
 
+import (
+	"fmt"
+	"net/url"
+
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
 type Hourly int64
 
 const (
@@ -17,6 +24,15 @@ const (
 	IntervalDayAverage Interval = 24
 )
 
+// ExpectedHourlyCount returns the number of hourly records per day the interval yields
+func (interval Interval) ExpectedHourlyCount() int {
+	if interval <= 0 {
+		return 0
+	}
+
+	return 24 / int(interval)
+}
+
 type Units string
 
 const (
@@ -24,3 +40,139 @@ const (
 	UnitsScientific Units = "s"
 	UnitsFahrenheit Units = "f"
 )
+
+func (interval Interval) IsValid() bool {
+	switch interval {
+	case Interval1Hour, Interval3Hours, Interval6Hours, IntervalDayNight, IntervalDayAverage:
+		return true
+	}
+
+	return false
+}
+
+func (units Units) IsValid() bool {
+	switch units {
+	case UnitsMetric, UnitsScientific, UnitsFahrenheit:
+		return true
+	}
+
+	return false
+}
+
+type Language string
+
+// languages supported by the language parameter, English being the default
+const (
+	LanguageEnglish            Language = "en"
+	LanguageArabic             Language = "ar"
+	LanguageBengali            Language = "bn"
+	LanguageBulgarian          Language = "bg"
+	LanguageChineseSimplified  Language = "zh"
+	LanguageChineseTraditional Language = "zh_tw"
+	LanguageCzech              Language = "cs"
+	LanguageDanish             Language = "da"
+	LanguageDutch              Language = "nl"
+	LanguageFinnish            Language = "fi"
+	LanguageFrench             Language = "fr"
+	LanguageGerman             Language = "de"
+	LanguageGreek              Language = "el"
+	LanguageHindi              Language = "hi"
+	LanguageHungarian          Language = "hu"
+	LanguageItalian            Language = "it"
+	LanguageJapanese           Language = "ja"
+	LanguageJavanese           Language = "jv"
+	LanguageKorean             Language = "ko"
+	LanguageMandarin           Language = "zh_cmn"
+	LanguageMarathi            Language = "mr"
+	LanguagePolish             Language = "pl"
+	LanguagePortuguese         Language = "pt"
+	LanguagePunjabi            Language = "pa"
+	LanguageRomanian           Language = "ro"
+	LanguageRussian            Language = "ru"
+	LanguageSerbian            Language = "sr"
+	LanguageSinhalese          Language = "si"
+	LanguageSlovak             Language = "sk"
+	LanguageSpanish            Language = "es"
+	LanguageSwedish            Language = "sv"
+	LanguageTamil              Language = "ta"
+	LanguageTelugu             Language = "te"
+	LanguageTurkish            Language = "tr"
+	LanguageUkrainian          Language = "uk"
+	LanguageUrdu               Language = "ur"
+	LanguageVietnamese         Language = "vi"
+	LanguageWu                 Language = "zh_wuu"
+	LanguageXiang              Language = "zh_hsn"
+	LanguageCantonese          Language = "zh_yue"
+	LanguageZulu               Language = "zu"
+)
+
+func (language Language) IsValid() bool {
+	switch language {
+	case LanguageEnglish, LanguageArabic, LanguageBengali, LanguageBulgarian, LanguageChineseSimplified,
+		LanguageChineseTraditional, LanguageCzech, LanguageDanish, LanguageDutch, LanguageFinnish,
+		LanguageFrench, LanguageGerman, LanguageGreek, LanguageHindi, LanguageHungarian, LanguageItalian,
+		LanguageJapanese, LanguageJavanese, LanguageKorean, LanguageMandarin, LanguageMarathi,
+		LanguagePolish, LanguagePortuguese, LanguagePunjabi, LanguageRomanian, LanguageRussian,
+		LanguageSerbian, LanguageSinhalese, LanguageSlovak, LanguageSpanish, LanguageSwedish,
+		LanguageTamil, LanguageTelugu, LanguageTurkish, LanguageUkrainian, LanguageUrdu,
+		LanguageVietnamese, LanguageWu, LanguageXiang, LanguageCantonese, LanguageZulu:
+		return true
+	}
+
+	return false
+}
+
+// validateParameters validates the parameters shared by the endpoints, nil values are not validated
+func validateParameters(units *Units, interval *Interval, language *Language) *errortools.Error {
+	var v violations
+
+	if units != nil && !units.IsValid() {
+		v.addf("Invalid Units: %s", string(*units))
+	}
+
+	if interval != nil && !interval.IsValid() {
+		v.addf("Invalid Interval: %v", int64(*interval))
+	}
+
+	if language != nil && !language.IsValid() {
+		v.addf("Unsupported Language: %s", string(*language))
+	}
+
+	return v.error()
+}
+
+// mergeExtraParams merges extra into values, replacing parameters with the same name.
+// access_key is always set by the service.
+func mergeExtraParams(values url.Values, extra url.Values) {
+	for key, extraValues := range extra {
+		if key == "access_key" {
+			continue
+		}
+		values[key] = append([]string(nil), extraValues...)
+	}
+}
+
+// baseValues returns the query parameters shared by the endpoints, nil parameters are omitted
+func baseValues(query string, units *Units, hourly *Hourly, interval *Interval, language *Language) url.Values {
+	values := url.Values{}
+
+	values.Add("query", query)
+
+	if hourly != nil {
+		values.Add("hourly", fmt.Sprintf("%v", int64(*hourly)))
+	}
+
+	if interval != nil {
+		values.Add("interval", fmt.Sprintf("%v", int64(*interval)))
+	}
+
+	if units != nil {
+		values.Add("units", string(*units))
+	}
+
+	if language != nil {
+		values.Add("language", string(*language))
+	}
+
+	return values
+}
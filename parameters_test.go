@@ -0,0 +1,89 @@
+package weatherstack
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBaseValues(t *testing.T) {
+	metric, fahrenheit := UnitsMetric, UnitsFahrenheit
+	dutch := LanguageDutch
+	hourlyOn := HourlyOn
+	interval := Interval3Hours
+
+	tests := []struct {
+		name     string
+		units    *Units
+		hourly   *Hourly
+		interval *Interval
+		language *Language
+		want     url.Values
+	}{
+		{"query only", nil, nil, nil, nil, url.Values{"query": {"Amsterdam"}}},
+		{"metric units", &metric, nil, nil, nil, url.Values{"query": {"Amsterdam"}, "units": {"m"}}},
+		{"fahrenheit units", &fahrenheit, nil, nil, nil, url.Values{"query": {"Amsterdam"}, "units": {"f"}}},
+		{"language", nil, nil, nil, &dutch, url.Values{"query": {"Amsterdam"}, "language": {"nl"}}},
+		{"hourly and interval", nil, &hourlyOn, &interval, nil, url.Values{"query": {"Amsterdam"}, "hourly": {"1"}, "interval": {"3"}}},
+		{"all", &metric, &hourlyOn, &interval, &dutch, url.Values{
+			"query":    {"Amsterdam"},
+			"units":    {"m"},
+			"hourly":   {"1"},
+			"interval": {"3"},
+			"language": {"nl"},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := baseValues("Amsterdam", test.units, test.hourly, test.interval, test.language)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("baseValues() = %v, want %v", got, test.want)
+			}
+			if _, ok := got["access_key"]; ok {
+				t.Errorf("baseValues() contains access_key")
+			}
+		})
+	}
+}
+
+func TestMergeExtraParamsExcludesAccessKey(t *testing.T) {
+	values := url.Values{"query": {"Amsterdam"}}
+	mergeExtraParams(values, url.Values{"access_key": {"leaked"}, "query": {"Berlin"}, "foo": {"bar"}})
+
+	want := url.Values{"query": {"Berlin"}, "foo": {"bar"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("mergeExtraParams() = %v, want %v", values, want)
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	validUnits, invalidUnits := UnitsScientific, Units("x")
+	validInterval, invalidInterval := Interval6Hours, Interval(5)
+	validLanguage, invalidLanguage := LanguageGerman, Language("xx")
+
+	tests := []struct {
+		name     string
+		units    *Units
+		interval *Interval
+		language *Language
+		wantErr  bool
+	}{
+		{"nil parameters", nil, nil, nil, false},
+		{"valid parameters", &validUnits, &validInterval, &validLanguage, false},
+		{"invalid units", &invalidUnits, nil, nil, true},
+		{"invalid interval", nil, &invalidInterval, nil, true},
+		{"unsupported language", nil, nil, &invalidLanguage, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := validateParameters(test.units, test.interval, test.language)
+
+			if (e != nil) != test.wantErr {
+				t.Errorf("validateParameters() error = %v, want error %v", e, test.wantErr)
+			}
+		})
+	}
+}
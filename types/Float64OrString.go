@@ -0,0 +1,34 @@
+package weatherstack
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Float64OrString unmarshals both a number and a quoted number, "", "null" and null unmarshal to zero
+type Float64OrString float64
+
+func (d *Float64OrString) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), " ")
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = strings.Trim(unquoted, " ")
+	}
+
+	if s == "" || s == "null" {
+		*d = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	*d = Float64OrString(f)
+	return nil
+}
+
+func (d Float64OrString) Value() float64 {
+	return float64(d)
+}
@@ -0,0 +1,105 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFloat64OrStringUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    float64
+		wantErr bool
+	}{
+		{`12.5`, 12.5, false},
+		{`-3`, -3, false},
+		{`"12.5"`, 12.5, false},
+		{`" 7 "`, 7, false},
+		{`""`, 0, false},
+		{`"null"`, 0, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var v struct {
+				F Float64OrString `json:"f"`
+			}
+			err := json.Unmarshal([]byte(`{"f":`+test.json+`}`), &v)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", test.json, err, test.wantErr)
+			}
+			if err == nil && v.F.Value() != test.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", test.json, v.F.Value(), test.want)
+			}
+		})
+	}
+}
+
+func TestNullFloat64OrStringUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json      string
+		want      float64
+		wantValid bool
+		wantErr   bool
+	}{
+		{`12.5`, 12.5, true, false},
+		{`0`, 0, true, false},
+		{`"12.5"`, 12.5, true, false},
+		{`"0"`, 0, true, false},
+		{`""`, 0, false, false},
+		{`"null"`, 0, false, false},
+		{`null`, 0, false, false},
+		{`"abc"`, 0, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var v struct {
+				F NullFloat64OrString `json:"f"`
+			}
+			err := json.Unmarshal([]byte(`{"f":`+test.json+`}`), &v)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", test.json, err, test.wantErr)
+			}
+			if err == nil && (v.F.Value() != test.want || v.F.Valid != test.wantValid) {
+				t.Errorf("Unmarshal(%s) = %+v, want {Float64:%v Valid:%v}", test.json, v.F, test.want, test.wantValid)
+			}
+		})
+	}
+}
+
+func TestNullFloat64OrStringMissing(t *testing.T) {
+	var v struct {
+		F NullFloat64OrString `json:"f"`
+	}
+	if err := json.Unmarshal([]byte(`{}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.F.Valid || v.F.Ptr() != nil {
+		t.Errorf("missing field = %+v, want invalid", v.F)
+	}
+}
+
+func TestNullFloat64OrStringMarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		value NullFloat64OrString
+		want  string
+	}{
+		{NullFloat64OrString{Float64: 1.5, Valid: true}, `1.5`},
+		{NullFloat64OrString{Float64: 0, Valid: true}, `0`},
+		{NullFloat64OrString{}, `null`},
+	} {
+		b, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.want {
+			t.Errorf("Marshal(%+v) = %s, want %s", test.value, b, test.want)
+		}
+	}
+}
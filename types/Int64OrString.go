@@ -0,0 +1,42 @@
+package weatherstack
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Int64OrString unmarshals both a number and a quoted number, "", "null" and null unmarshal to zero.
+// Decimal values are rounded.
+type Int64OrString int64
+
+func (d *Int64OrString) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), " ")
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = strings.Trim(unquoted, " ")
+	}
+
+	if s == "" || s == "null" {
+		*d = 0
+		return nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		*d = Int64OrString(i)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	*d = Int64OrString(math.Round(f))
+	return nil
+}
+
+func (d Int64OrString) Value() int64 {
+	return int64(d)
+}
@@ -0,0 +1,94 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInt64OrStringUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{`42`, 42, false},
+		{`-7`, -7, false},
+		{`"42"`, 42, false},
+		{`" 42 "`, 42, false},
+		{`12.6`, 13, false},
+		{`"12.4"`, 12, false},
+		{`""`, 0, false},
+		{`"null"`, 0, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var v struct {
+				I Int64OrString `json:"i"`
+			}
+			err := json.Unmarshal([]byte(`{"i":`+test.json+`}`), &v)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", test.json, err, test.wantErr)
+			}
+			if err == nil && v.I.Value() != test.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", test.json, v.I.Value(), test.want)
+			}
+		})
+	}
+}
+
+func TestNullInt64OrStringUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json      string
+		want      int64
+		wantValid bool
+		wantErr   bool
+	}{
+		{`42`, 42, true, false},
+		{`0`, 0, true, false},
+		{`"42"`, 42, true, false},
+		{`"0"`, 0, true, false},
+		{`""`, 0, false, false},
+		{`"null"`, 0, false, false},
+		{`null`, 0, false, false},
+		{`"abc"`, 0, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var v struct {
+				I NullInt64OrString `json:"i"`
+			}
+			err := json.Unmarshal([]byte(`{"i":`+test.json+`}`), &v)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", test.json, err, test.wantErr)
+			}
+			if err == nil && (v.I.Value() != test.want || v.I.Valid != test.wantValid) {
+				t.Errorf("Unmarshal(%s) = %+v, want {Int64:%v Valid:%v}", test.json, v.I, test.want, test.wantValid)
+			}
+		})
+	}
+}
+
+func TestNullInt64OrStringMarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		value NullInt64OrString
+		want  string
+	}{
+		{NullInt64OrString{Int64: 3, Valid: true}, `3`},
+		{NullInt64OrString{Int64: 0, Valid: true}, `0`},
+		{NullInt64OrString{}, `null`},
+	} {
+		b, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.want {
+			t.Errorf("Marshal(%+v) = %s, want %s", test.value, b, test.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package weatherstack
+
+import "encoding/json"
+
+// NullFloat64OrString is a Float64OrString that may be absent: Valid is false if the field is missing,
+// null or "", distinguishing an absent value from zero
+type NullFloat64OrString struct {
+	Float64 float64
+	Valid   bool
+}
+
+func (d *NullFloat64OrString) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*d = NullFloat64OrString{}
+		return nil
+	}
+
+	var f Float64OrString
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+
+	*d = NullFloat64OrString{Float64: f.Value(), Valid: true}
+	return nil
+}
+
+func (d NullFloat64OrString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Ptr())
+}
+
+// Value returns the value, zero if it is absent
+func (d NullFloat64OrString) Value() float64 {
+	return d.Float64
+}
+
+// Ptr returns the value, nil if it is absent
+func (d NullFloat64OrString) Ptr() *float64 {
+	if !d.Valid {
+		return nil
+	}
+
+	f := d.Float64
+	return &f
+}
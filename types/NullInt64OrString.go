@@ -0,0 +1,59 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// NullInt64OrString is an Int64OrString that may be absent: Valid is false if the field is missing,
+// null or "", distinguishing an absent value from zero
+type NullInt64OrString struct {
+	Int64 int64
+	Valid bool
+}
+
+func (d *NullInt64OrString) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*d = NullInt64OrString{}
+		return nil
+	}
+
+	var i Int64OrString
+	if err := json.Unmarshal(b, &i); err != nil {
+		return err
+	}
+
+	*d = NullInt64OrString{Int64: i.Value(), Valid: true}
+	return nil
+}
+
+func (d NullInt64OrString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Ptr())
+}
+
+// Value returns the value, zero if it is absent
+func (d NullInt64OrString) Value() int64 {
+	return d.Int64
+}
+
+// Ptr returns the value, nil if it is absent
+func (d NullInt64OrString) Ptr() *int64 {
+	if !d.Valid {
+		return nil
+	}
+
+	i := d.Int64
+	return &i
+}
+
+// isNull reports whether b is null, "" or "null", possibly surrounded by spaces
+func isNull(b []byte) bool {
+	s := strings.Trim(string(b), " ")
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = strings.Trim(unquoted, " ")
+	}
+
+	return s == "" || s == "null"
+}
@@ -0,0 +1,27 @@
+package weatherstack
+
+import (
+	"strconv"
+	"strings"
+)
+
+// YesNoString unmarshals "yes" to true and anything else to false, null leaving it unset
+type YesNoString bool
+
+func (d *YesNoString) UnmarshalJSON(b []byte) error {
+	if strings.Trim(string(b), " ") == "null" {
+		return nil
+	}
+
+	unquoted, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = YesNoString(strings.EqualFold(strings.Trim(unquoted, " "), "yes"))
+	return nil
+}
+
+func (d YesNoString) Value() bool {
+	return bool(d)
+}
@@ -0,0 +1,45 @@
+package weatherstack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestYesNoStringUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    bool
+		wantErr bool
+	}{
+		{`"yes"`, true, false},
+		{`" YES "`, true, false},
+		{`"no"`, false, false},
+		{`""`, false, false},
+		{`null`, false, false},
+		{` null`, false, false},
+		{`true`, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			var v struct {
+				IsDay YesNoString `json:"is_day"`
+			}
+			err := json.Unmarshal([]byte(`{"is_day":`+test.json+`}`), &v)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", test.json, err, test.wantErr)
+			}
+			if err == nil && v.IsDay.Value() != test.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", test.json, v.IsDay.Value(), test.want)
+			}
+		})
+	}
+}
+
+func TestYesNoStringUnmarshalNullKeepsValue(t *testing.T) {
+	v := YesNoString(true)
+	if err := v.UnmarshalJSON([]byte(`null`)); err != nil || !v.Value() {
+		t.Errorf("UnmarshalJSON(null) = %v, %v, want true, nil", v.Value(), err)
+	}
+}
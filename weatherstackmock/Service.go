@@ -0,0 +1,188 @@
+// Package weatherstackmock provides a fake weatherstack.WeatherService returning canned responses
+package weatherstackmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	weatherstack "github.com/leapforce-libraries/go_weatherstack"
+)
+
+// Call records a call of one of the endpoint methods
+type Call struct {
+	Method string
+	Query  string      // Query of the config, or the query of its Coordinates
+	Config interface{} // the config passed, or the query for Autocomplete
+}
+
+// Service returns the canned response for the query of each call.
+// An error set for the query in Errors is returned instead, a query without response or error gets an error.
+// The canned responses are returned as is, so callers should not modify them.
+// Service is safe for concurrent use, provided the maps are not modified after the first call.
+type Service struct {
+	CurrentResponses      map[string]*weatherstack.CurrentResponse
+	HistoricalResponses   map[string]*weatherstack.HistoricalResponse
+	ForecastResponses     map[string]*weatherstack.ForecastResponse
+	MarineResponses       map[string]*weatherstack.MarineResponse
+	AutocompleteResponses map[string]*weatherstack.AutocompleteResponse
+	Errors                map[string]*weatherstack.RequestError
+	mutex                 sync.Mutex
+	calls                 []Call
+}
+
+var _ weatherstack.WeatherService = (*Service)(nil)
+
+func NewService() *Service {
+	return &Service{
+		CurrentResponses:      make(map[string]*weatherstack.CurrentResponse),
+		HistoricalResponses:   make(map[string]*weatherstack.HistoricalResponse),
+		ForecastResponses:     make(map[string]*weatherstack.ForecastResponse),
+		MarineResponses:       make(map[string]*weatherstack.MarineResponse),
+		AutocompleteResponses: make(map[string]*weatherstack.AutocompleteResponse),
+		Errors:                make(map[string]*weatherstack.RequestError),
+	}
+}
+
+// Calls returns the calls made so far, in order
+func (service *Service) Calls() []Call {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	return append([]Call{}, service.calls...)
+}
+
+// Reset clears the recorded calls
+func (service *Service) Reset() {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	service.calls = nil
+}
+
+// record records the call and returns the error for the query, if any
+func (service *Service) record(ctx context.Context, method string, query string, coordinates *weatherstack.Coordinates, config interface{}) (string, *weatherstack.RequestError) {
+	if coordinates != nil {
+		query = coordinates.Query()
+	}
+
+	service.mutex.Lock()
+	service.calls = append(service.calls, Call{Method: method, Query: query, Config: config})
+	service.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return query, &weatherstack.RequestError{Err: err}
+	}
+
+	if e, ok := service.Errors[query]; ok {
+		return query, e
+	}
+
+	return query, nil
+}
+
+func noResponse(method string, query string) *weatherstack.RequestError {
+	return &weatherstack.RequestError{Err: fmt.Errorf("weatherstackmock: no %s response for query %s", method, query)}
+}
+
+func (service *Service) GetCurrentWeather(config weatherstack.GetCurrentWeatherConfig) (*weatherstack.CurrentResponse, *weatherstack.RequestError) {
+	return service.GetCurrentWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetCurrentWeatherWithContext(ctx context.Context, config weatherstack.GetCurrentWeatherConfig) (*weatherstack.CurrentResponse, *weatherstack.RequestError) {
+	query, e := service.record(ctx, "GetCurrentWeather", config.Query, config.Coordinates, config)
+	if e != nil {
+		return nil, e
+	}
+
+	response, ok := service.CurrentResponses[query]
+	if !ok {
+		return nil, noResponse("current", query)
+	}
+
+	return response, nil
+}
+
+func (service *Service) GetHistoricalWeather(config weatherstack.GetHistoricalWeatherConfig) (*weatherstack.HistoricalResponse, *weatherstack.RequestError) {
+	return service.GetHistoricalWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalWeatherWithContext(ctx context.Context, config weatherstack.GetHistoricalWeatherConfig) (*weatherstack.HistoricalResponse, *weatherstack.RequestError) {
+	return service.getHistoricalWeather(ctx, "GetHistoricalWeather", config)
+}
+
+func (service *Service) GetHistoricalWeatherRange(config weatherstack.GetHistoricalWeatherConfig) (*weatherstack.HistoricalResponse, *weatherstack.RequestError) {
+	return service.GetHistoricalWeatherRangeWithContext(context.Background(), config)
+}
+
+func (service *Service) GetHistoricalWeatherRangeWithContext(ctx context.Context, config weatherstack.GetHistoricalWeatherConfig) (*weatherstack.HistoricalResponse, *weatherstack.RequestError) {
+	return service.getHistoricalWeather(ctx, "GetHistoricalWeatherRange", config)
+}
+
+func (service *Service) getHistoricalWeather(ctx context.Context, method string, config weatherstack.GetHistoricalWeatherConfig) (*weatherstack.HistoricalResponse, *weatherstack.RequestError) {
+	query, e := service.record(ctx, method, config.Query, config.Coordinates, config)
+	if e != nil {
+		return nil, e
+	}
+
+	response, ok := service.HistoricalResponses[query]
+	if !ok {
+		return nil, noResponse("historical", query)
+	}
+
+	return response, nil
+}
+
+func (service *Service) GetForecastWeather(config weatherstack.GetForecastWeatherConfig) (*weatherstack.ForecastResponse, *weatherstack.RequestError) {
+	return service.GetForecastWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetForecastWeatherWithContext(ctx context.Context, config weatherstack.GetForecastWeatherConfig) (*weatherstack.ForecastResponse, *weatherstack.RequestError) {
+	query, e := service.record(ctx, "GetForecastWeather", config.Query, config.Coordinates, config)
+	if e != nil {
+		return nil, e
+	}
+
+	response, ok := service.ForecastResponses[query]
+	if !ok {
+		return nil, noResponse("forecast", query)
+	}
+
+	return response, nil
+}
+
+func (service *Service) GetMarineWeather(config weatherstack.GetMarineWeatherConfig) (*weatherstack.MarineResponse, *weatherstack.RequestError) {
+	return service.GetMarineWeatherWithContext(context.Background(), config)
+}
+
+func (service *Service) GetMarineWeatherWithContext(ctx context.Context, config weatherstack.GetMarineWeatherConfig) (*weatherstack.MarineResponse, *weatherstack.RequestError) {
+	query, e := service.record(ctx, "GetMarineWeather", config.Query, config.Coordinates, config)
+	if e != nil {
+		return nil, e
+	}
+
+	response, ok := service.MarineResponses[query]
+	if !ok {
+		return nil, noResponse("marine", query)
+	}
+
+	return response, nil
+}
+
+func (service *Service) Autocomplete(query string) (*weatherstack.AutocompleteResponse, *weatherstack.RequestError) {
+	return service.AutocompleteWithContext(context.Background(), query)
+}
+
+func (service *Service) AutocompleteWithContext(ctx context.Context, query string) (*weatherstack.AutocompleteResponse, *weatherstack.RequestError) {
+	query, e := service.record(ctx, "Autocomplete", query, nil, query)
+	if e != nil {
+		return nil, e
+	}
+
+	response, ok := service.AutocompleteResponses[query]
+	if !ok {
+		return nil, noResponse("autocomplete", query)
+	}
+
+	return response, nil
+}
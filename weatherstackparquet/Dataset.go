@@ -0,0 +1,47 @@
+// Package weatherstackparquet converts historical and forecast responses into Apache Arrow records and Parquet files
+// with a stable typed schema, see DailySchema and HourlySchema.
+// It is a separate module so the Arrow dependencies and their Go version are not imposed on users of the client.
+package weatherstackparquet
+
+import (
+	"sort"
+
+	weatherstack "github.com/leapforce-libraries/go_weatherstack"
+)
+
+// Dataset is a set of days along with the location and units of the response they were taken from
+type Dataset struct {
+	Location weatherstack.Location
+	Units    string                 // unit of the request, e.g. "m", "s" or "f"
+	Days     []weatherstack.Weather // rows are written in the order of Days
+}
+
+// FromHistorical returns the days of the response in chronological order
+func FromHistorical(r *weatherstack.HistoricalResponse) Dataset {
+	return Dataset{
+		Location: r.Location,
+		Units:    r.Request.Unit,
+		Days:     r.Days(),
+	}
+}
+
+// FromForecast returns the forecast days of the response in chronological order
+func FromForecast(r *weatherstack.ForecastResponse) Dataset {
+	dates := make([]string, 0, len(r.Forecast))
+	for date := range r.Forecast {
+		dates = append(dates, date)
+	}
+
+	sort.Strings(dates)
+
+	days := make([]weatherstack.Weather, len(dates))
+	for i, date := range dates {
+		days[i] = r.Forecast[date]
+	}
+
+	return Dataset{
+		Location: r.Location,
+		Units:    r.Request.Unit,
+		Days:     days,
+	}
+}
@@ -0,0 +1,57 @@
+package weatherstackparquet
+
+import (
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	errortools "github.com/leapforce-libraries/go_errortools"
+)
+
+// WriteDailyParquet writes the days of the datasets to w as a Snappy compressed Parquet file, see NewDailyRecord
+func WriteDailyParquet(w io.Writer, datasets ...Dataset) *errortools.Error {
+	record, e := NewDailyRecord(nil, datasets...)
+	if e != nil {
+		return e
+	}
+	defer record.Release()
+
+	return WriteParquet(w, record)
+}
+
+// WriteHourlyParquet writes the hourly records of the datasets to w as a Snappy compressed Parquet file, see NewHourlyRecord
+func WriteHourlyParquet(w io.Writer, datasets ...Dataset) *errortools.Error {
+	record, e := NewHourlyRecord(nil, datasets...)
+	if e != nil {
+		return e
+	}
+	defer record.Release()
+
+	return WriteParquet(w, record)
+}
+
+// WriteParquet writes record to w as a Snappy compressed Parquet file in a single row group.
+// The Arrow schema is stored in the file, so readers using Arrow get the dictionary and time zone types back. w is not closed.
+func WriteParquet(w io.Writer, record arrow.RecordBatch) *errortools.Error {
+	// hide any Close method of w, pqarrow closing writers implementing io.Closer
+	writer, err := pqarrow.NewFileWriter(record.Schema(), struct{ io.Writer }{w},
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+		pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()),
+	)
+	if err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	if err := writer.Write(record); err != nil {
+		writer.Close()
+		return errortools.ErrorMessage(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return errortools.ErrorMessage(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package weatherstackparquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	weatherstack "github.com/leapforce-libraries/go_weatherstack"
+	w_types "github.com/leapforce-libraries/go_weatherstack/types"
+)
+
+func readHistorical(t *testing.T) *weatherstack.HistoricalResponse {
+	b, err := ioutil.ReadFile("../testdata/historical_scientific.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	historicalResponse := weatherstack.HistoricalResponse{}
+	if err := json.Unmarshal(b, &historicalResponse); err != nil {
+		t.Fatal(err)
+	}
+
+	return &historicalResponse
+}
+
+func readParquet(t *testing.T, b []byte) arrow.Table {
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(b), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(table.Release)
+
+	return table
+}
+
+func assertSchema(t *testing.T, got *arrow.Schema, want *arrow.Schema) {
+	if got.NumFields() != want.NumFields() {
+		t.Fatalf("got %v fields, want %v", got.NumFields(), want.NumFields())
+	}
+
+	for i, field := range want.Fields() {
+		gotField := got.Field(i)
+		if gotField.Name != field.Name || !arrow.TypeEqual(gotField.Type, field.Type) || gotField.Nullable != field.Nullable {
+			t.Errorf("field %v = %s, want %s", i, gotField, field)
+		}
+	}
+}
+
+func tableColumn(t *testing.T, table arrow.Table, name string) arrow.Array {
+	indices := table.Schema().FieldIndices(name)
+	if len(indices) != 1 {
+		t.Fatalf("no column %s", name)
+	}
+
+	return table.Column(indices[0]).Data().Chunk(0)
+}
+
+func TestWriteHourlyParquet(t *testing.T) {
+	historicalResponse := readHistorical(t)
+
+	// an hourly record without the optional fields, before the one of the fixture
+	day := historicalResponse.Historical["2021-09-09"]
+	day.Hourly = append(day.Hourly, weatherstack.HourlyWeather{Time: 300, WeatherCode: weatherstack.WeatherCodeSunny})
+	day.Hourly[0], day.Hourly[1] = day.Hourly[1], day.Hourly[0]
+	historicalResponse.Historical["2021-09-09"] = day
+
+	buffer := bytes.Buffer{}
+	if e := WriteHourlyParquet(&buffer, FromHistorical(historicalResponse)); e != nil {
+		t.Fatal(e.Message())
+	}
+
+	table := readParquet(t, buffer.Bytes())
+	assertSchema(t, table.Schema(), HourlySchema())
+	if table.NumRows() != 2 {
+		t.Fatalf("got %v rows, want 2", table.NumRows())
+	}
+
+	timestamps := tableColumn(t, table, "timestamp").(*array.Timestamp)
+	want := time.Date(2021, time.September, 9, 0, 0, 0, 0, time.UTC).Add(-2 * time.Hour).UnixMilli()
+	if got := int64(timestamps.Value(0)); got != want {
+		t.Errorf("timestamp = %v, want %v", got, want)
+	}
+	if got := tableColumn(t, table, "time").(*array.Time32).Value(1); got != 3*3600*1000 {
+		t.Errorf("time = %v, want %v", got, 3*3600*1000)
+	}
+
+	if got := tableColumn(t, table, "temperature").(*array.Float64).Value(0); got != 288.35 {
+		t.Errorf("temperature = %v, want 288.35", got)
+	}
+
+	dewpoints := tableColumn(t, table, "dewpoint").(*array.Float64)
+	if dewpoints.IsNull(0) || dewpoints.Value(0) != 286.15 || !dewpoints.IsNull(1) {
+		t.Errorf("dewpoint = %s, want [286.15 (null)]", dewpoints)
+	}
+
+	if got := tableColumn(t, table, "weather_code").(*array.Int32).Value(0); got != 113 {
+		t.Errorf("weather_code = %v, want 113", got)
+	}
+
+	units := tableColumn(t, table, "units").(*array.Dictionary)
+	if got := units.Dictionary().(*array.String).Value(units.GetValueIndex(1)); got != "s" {
+		t.Errorf("units = %q, want \"s\"", got)
+	}
+}
+
+func TestWriteDailyParquet(t *testing.T) {
+	historicalResponse := readHistorical(t)
+
+	day := historicalResponse.Historical["2021-09-09"]
+	day.Date = w_types.DateString(time.Date(2021, time.September, 10, 0, 0, 0, 0, time.UTC))
+	day.Astro.Moonrise = w_types.TimeStruct{TimeString: "No moonrise"}
+	historicalResponse.Historical["2021-09-10"] = day
+
+	buffer := bytes.Buffer{}
+	if e := WriteDailyParquet(&buffer, FromHistorical(historicalResponse)); e != nil {
+		t.Fatal(e.Message())
+	}
+
+	table := readParquet(t, buffer.Bytes())
+	assertSchema(t, table.Schema(), DailySchema())
+	if table.NumRows() != 2 {
+		t.Fatalf("got %v rows, want 2", table.NumRows())
+	}
+
+	dates := tableColumn(t, table, "date").(*array.Date32)
+	if got := dates.Value(1).ToTime(); !got.Equal(time.Date(2021, time.September, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %s, want 2021-09-10", got)
+	}
+
+	sunrises := tableColumn(t, table, "sunrise").(*array.Time32)
+	if got := sunrises.Value(0); got != (7*3600+6*60)*1000 {
+		t.Errorf("sunrise = %v, want %v", got, (7*3600+6*60)*1000)
+	}
+
+	if moonrises := tableColumn(t, table, "moonrise").(*array.Time32); moonrises.IsNull(0) || !moonrises.IsNull(1) {
+		t.Errorf("moonrise = %s, want a value and null", moonrises)
+	}
+}
@@ -0,0 +1,289 @@
+package weatherstackparquet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	errortools "github.com/leapforce-libraries/go_errortools"
+	weatherstack "github.com/leapforce-libraries/go_weatherstack"
+)
+
+var (
+	categorical  = &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+	localTime    = arrow.FixedWidthTypes.Time32ms // Parquet has no time or timestamp in seconds
+	utcTimestamp = arrow.FixedWidthTypes.Timestamp_ms
+	date         = arrow.FixedWidthTypes.Date32
+	float64Type  = arrow.PrimitiveTypes.Float64
+	int32Type    = arrow.PrimitiveTypes.Int32
+	int64Type    = arrow.PrimitiveTypes.Int64
+	booleanType  = arrow.FixedWidthTypes.Boolean
+	dailySchema  = schemaOf(dailyColumns)
+	hourlySchema = schemaOf(hourlyColumns)
+)
+
+type column[T any] struct {
+	name     string
+	dataType arrow.DataType
+	nullable bool
+	value    func(row T) interface{} // nil, or a nil *float64 or *int64, for null
+}
+
+type dailyRow struct {
+	dataset Dataset
+	day     weatherstack.Weather
+	date    civil.Date
+}
+
+type hourlyRow struct {
+	dataset Dataset
+	date    civil.Date
+	loc     *time.Location // nil if the location's time zone cannot be determined
+	hourly  weatherstack.HourlyWeather
+}
+
+var dailyColumns = []column[dailyRow]{
+	{"location", categorical, false, func(r dailyRow) interface{} { return r.dataset.Location.Name }},
+	{"lat", float64Type, false, func(r dailyRow) interface{} { return float64(r.dataset.Location.Lat) }},
+	{"lon", float64Type, false, func(r dailyRow) interface{} { return float64(r.dataset.Location.Lon) }},
+	{"date", date, false, func(r dailyRow) interface{} { return date32Of(r.date) }},
+	{"units", categorical, false, func(r dailyRow) interface{} { return r.dataset.Units }},
+	{"mintemp", float64Type, false, func(r dailyRow) interface{} { return r.day.MinTemp.Value() }},
+	{"maxtemp", float64Type, false, func(r dailyRow) interface{} { return r.day.MaxTemp.Value() }},
+	{"avgtemp", float64Type, false, func(r dailyRow) interface{} { return r.day.AvgTemp.Value() }},
+	{"totalsnow", float64Type, false, func(r dailyRow) interface{} { return r.day.TotalSnow.Value() }},
+	{"sunhour", float64Type, false, func(r dailyRow) interface{} { return r.day.SunHour.Value() }},
+	{"uv_index", int64Type, false, func(r dailyRow) interface{} { return r.day.UVIndex.Value() }},
+	{"sunrise", localTime, true, func(r dailyRow) interface{} { return time32Of(r.day.Astro.SunriseTime(r.date, nil)) }},
+	{"sunset", localTime, true, func(r dailyRow) interface{} { return time32Of(r.day.Astro.SunsetTime(r.date, nil)) }},
+	{"moonrise", localTime, true, func(r dailyRow) interface{} { return time32Of(r.day.Astro.MoonriseTime(r.date, nil)) }},
+	{"moonset", localTime, true, func(r dailyRow) interface{} { return time32Of(r.day.Astro.MoonsetTime(r.date, nil)) }},
+	{"moon_phase", categorical, false, func(r dailyRow) interface{} { return r.day.Astro.MoonPhase }},
+	{"moon_illumination", int64Type, false, func(r dailyRow) interface{} { return r.day.Astro.MoonIllumination.Value() }},
+}
+
+var hourlyColumns = []column[hourlyRow]{
+	{"location", categorical, false, func(r hourlyRow) interface{} { return r.dataset.Location.Name }},
+	{"date", date, false, func(r hourlyRow) interface{} { return date32Of(r.date) }},
+	{"time", localTime, false, func(r hourlyRow) interface{} { return time32Of(r.hourly.TimeOfDay()) }},
+	{"timestamp", utcTimestamp, true, func(r hourlyRow) interface{} {
+		if r.loc == nil {
+			return nil
+		}
+		t, err := r.hourly.TimeOn(r.date, r.loc)
+		if err != nil {
+			return nil
+		}
+		return arrow.Timestamp(t.UnixMilli())
+	}},
+	{"units", categorical, false, func(r hourlyRow) interface{} { return r.dataset.Units }},
+	{"temperature", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.Temperature.Value() }},
+	{"feelslike", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.FeelsLike.Value() }},
+	{"wind_speed", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.WindSpeed.Value() }},
+	{"wind_degree", int64Type, false, func(r hourlyRow) interface{} { return r.hourly.WindDegree.Value() }},
+	{"wind_dir", categorical, false, func(r hourlyRow) interface{} { return r.hourly.WindDir }},
+	{"weather_code", int32Type, false, func(r hourlyRow) interface{} { return int32(r.hourly.WeatherCode) }},
+	{"weather_descriptions", categorical, false, func(r hourlyRow) interface{} {
+		return strings.Join(r.hourly.WeatherDescriptions, ", ")
+	}},
+	{"precip", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.Precip.Value() }},
+	{"humidity", int64Type, false, func(r hourlyRow) interface{} { return r.hourly.Humidity.Value() }},
+	{"visibility", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.Visibility.Value() }},
+	{"pressure", float64Type, false, func(r hourlyRow) interface{} { return r.hourly.Pressure.Value() }},
+	{"cloudcover", int64Type, false, func(r hourlyRow) interface{} { return r.hourly.Cloudcover.Value() }},
+	{"heatindex", float64Type, true, func(r hourlyRow) interface{} { return r.hourly.Heatindex.Ptr() }},
+	{"dewpoint", float64Type, true, func(r hourlyRow) interface{} { return r.hourly.Dewpoint.Ptr() }},
+	{"windchill", float64Type, true, func(r hourlyRow) interface{} { return r.hourly.Windchill.Ptr() }},
+	{"windgust", float64Type, true, func(r hourlyRow) interface{} { return r.hourly.Windgust.Ptr() }},
+	{"chanceofrain", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfRain.Ptr() }},
+	{"chanceofremdry", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfRemDry.Ptr() }},
+	{"chanceofwindy", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfWindy.Ptr() }},
+	{"chanceofovercast", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfOvercast.Ptr() }},
+	{"chanceofsunshine", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfSunshine.Ptr() }},
+	{"chanceoffrost", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfFrost.Ptr() }},
+	{"chanceofhightemp", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfHighTemp.Ptr() }},
+	{"chanceoffog", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfFog.Ptr() }},
+	{"chanceofsnow", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfSnow.Ptr() }},
+	{"chanceofthunder", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.ChanceOfThunder.Ptr() }},
+	{"uv_index", int64Type, true, func(r hourlyRow) interface{} { return r.hourly.UVIndex.Ptr() }},
+	{"interpolated", booleanType, false, func(r hourlyRow) interface{} { return r.hourly.Interpolated }},
+}
+
+// DailySchema returns the schema of the records returned by NewDailyRecord.
+// Location, units and moon phase are dictionary encoded, astro times are local times of day, null if the event does not occur.
+func DailySchema() *arrow.Schema {
+	return dailySchema
+}
+
+// HourlySchema returns the schema of the records returned by NewHourlyRecord.
+// The string columns are dictionary encoded, as is weather_code in Parquet. Time is the local time of day and timestamp
+// the instant in UTC, null if the location's time zone cannot be determined. Fields that may be absent from a record are nullable.
+func HourlySchema() *arrow.Schema {
+	return hourlySchema
+}
+
+// NewDailyRecord returns a record with a row per day of the datasets, mem defaults to memory.DefaultAllocator.
+// The caller should release the record.
+func NewDailyRecord(mem memory.Allocator, datasets ...Dataset) (arrow.RecordBatch, *errortools.Error) {
+	rows := []dailyRow{}
+
+	for _, dataset := range datasets {
+		for _, day := range dataset.Days {
+			rows = append(rows, dailyRow{dataset: dataset, day: day, date: day.CivilDate()})
+		}
+	}
+
+	return newRecord(mem, dailySchema, dailyColumns, rows)
+}
+
+// NewHourlyRecord returns a record with a row per hourly record of the datasets, ordered by day and time of day.
+// Records with an invalid Time are skipped. mem defaults to memory.DefaultAllocator, the caller should release the record.
+func NewHourlyRecord(mem memory.Allocator, datasets ...Dataset) (arrow.RecordBatch, *errortools.Error) {
+	rows := []hourlyRow{}
+
+	for _, dataset := range datasets {
+		loc, err := dataset.Location.Location()
+		if err != nil {
+			loc = nil
+		}
+
+		for _, day := range dataset.Days {
+			hourly := append([]weatherstack.HourlyWeather(nil), day.Hourly...)
+			sort.SliceStable(hourly, func(i, j int) bool {
+				return hourly[i].Time < hourly[j].Time
+			})
+
+			for _, h := range hourly {
+				if _, err := h.TimeOfDay(); err != nil {
+					continue
+				}
+
+				rows = append(rows, hourlyRow{dataset: dataset, date: day.CivilDate(), loc: loc, hourly: h})
+			}
+		}
+	}
+
+	return newRecord(mem, hourlySchema, hourlyColumns, rows)
+}
+
+func schemaOf[T any](columns []column[T]) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		fields[i] = arrow.Field{Name: c.name, Type: c.dataType, Nullable: c.nullable}
+	}
+
+	return arrow.NewSchema(fields, nil)
+}
+
+func newRecord[T any](mem memory.Allocator, schema *arrow.Schema, columns []column[T], rows []T) (arrow.RecordBatch, *errortools.Error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+
+	builder := array.NewRecordBuilder(mem, schema)
+	defer builder.Release()
+
+	builder.Reserve(len(rows))
+
+	for _, row := range rows {
+		for i, c := range columns {
+			err := appendValue(builder.Field(i), c.value(row))
+			if err != nil {
+				return nil, errortools.ErrorMessagef("Column %s: %s", c.name, err.Error())
+			}
+		}
+	}
+
+	return builder.NewRecordBatch(), nil
+}
+
+func appendValue(builder array.Builder, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		builder.AppendNull()
+		return nil
+	case *float64:
+		if v == nil {
+			builder.AppendNull()
+			return nil
+		}
+		value = *v
+	case *int64:
+		if v == nil {
+			builder.AppendNull()
+			return nil
+		}
+		value = *v
+	}
+
+	ok := true
+
+	switch b := builder.(type) {
+	case *array.Float64Builder:
+		var v float64
+		if v, ok = value.(float64); ok {
+			b.Append(v)
+		}
+	case *array.Int32Builder:
+		var v int32
+		if v, ok = value.(int32); ok {
+			b.Append(v)
+		}
+	case *array.Int64Builder:
+		var v int64
+		if v, ok = value.(int64); ok {
+			b.Append(v)
+		}
+	case *array.BooleanBuilder:
+		var v bool
+		if v, ok = value.(bool); ok {
+			b.Append(v)
+		}
+	case *array.Date32Builder:
+		var v arrow.Date32
+		if v, ok = value.(arrow.Date32); ok {
+			b.Append(v)
+		}
+	case *array.Time32Builder:
+		var v arrow.Time32
+		if v, ok = value.(arrow.Time32); ok {
+			b.Append(v)
+		}
+	case *array.TimestampBuilder:
+		var v arrow.Timestamp
+		if v, ok = value.(arrow.Timestamp); ok {
+			b.Append(v)
+		}
+	case *array.BinaryDictionaryBuilder:
+		var v string
+		if v, ok = value.(string); ok {
+			return b.AppendString(v)
+		}
+	default:
+		return fmt.Errorf("no builder for type %s", builder.Type())
+	}
+
+	if !ok {
+		return fmt.Errorf("value %v of type %T does not match type %s", value, value, builder.Type())
+	}
+
+	return nil
+}
+
+func date32Of(d civil.Date) arrow.Date32 {
+	return arrow.Date32FromTime(time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC))
+}
+
+// time32Of returns the time of day of t in milliseconds, nil if err is not nil
+func time32Of(t time.Time, err error) interface{} {
+	if err != nil {
+		return nil
+	}
+
+	return arrow.Time32((t.Hour()*3600 + t.Minute()*60 + t.Second()) * 1000)
+}
@@ -0,0 +1,34 @@
+module github.com/leapforce-libraries/go_weatherstack/weatherstackparquet
+
+go 1.25.0
+
+require (
+	cloud.google.com/go v0.121.0
+	github.com/apache/arrow-go/v18 v18.8.0
+	github.com/leapforce-libraries/go_errortools v0.0.0-20210922200432-64334a07d517
+	github.com/leapforce-libraries/go_weatherstack v0.0.0-20261014172536-c0eb59f77ef6
+)
+
+require (
+	github.com/andybalholm/brotli v1.2.3 // indirect
+	github.com/apache/thrift v0.24.0 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/goccy/go-json v0.10.6 // indirect
+	github.com/google/flatbuffers v25.12.19+incompatible // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.19.2 // indirect
+	github.com/klauspost/cpuid/v2 v2.4.0 // indirect
+	github.com/leapforce-libraries/go_http v0.0.0-20210922200535-553e8da688a1 // indirect
+	github.com/leapforce-libraries/go_types v0.0.0-20210807150729-611963306a0e // indirect
+	github.com/leapforce-libraries/go_utilities v0.0.0-20210608160701-de10bf72e7fd // indirect
+	github.com/pierrec/lz4/v4 v4.1.29 // indirect
+	github.com/zeebo/xxh3 v1.1.0 // indirect
+	golang.org/x/exp v0.0.0-20260112195511-716be5621a96 // indirect
+	golang.org/x/net v0.58.0 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.41.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260526163538-3dc84a4a5aaa // indirect
+	google.golang.org/grpc v1.83.2 // indirect
+	google.golang.org/protobuf v1.36.12 // indirect
+)